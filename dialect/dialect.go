@@ -1,5 +1,125 @@
 package dialect
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrorNotSupported = errors.New("not supported by the dialect")
+)
+
+// Set operators to combine the results of select statements
+const (
+	SetOperatorUnion     = "UNION"
+	SetOperatorUnionAll  = "UNION ALL"
+	SetOperatorIntersect = "INTERSECT"
+	SetOperatorExcept    = "EXCEPT"
+)
+
+// Dialect SQL dialect of the database
+//
+// Statements quote identifiers by QuoteIdentifier and number placeholders by Placeholder
+// in the order of the bindings while they are built.
+type Dialect interface {
+	// Name returns the name of the dialect, which is same as the driver name
+	Name() string
+
+	// QuoteIdentifier returns the quoted identifier(table name, column name and so on)
+	QuoteIdentifier(name string) string
+
+	// Placeholder returns the placeholder for the n-th(1-origin) binding
+	Placeholder(n int) string
+
+	// LimitOffset returns the clause to limit the result
+	LimitOffset(limit int32, offset int64) string
+
+	// InsertIgnore returns the keyword to insert ignoring the duplicated record, which replaces `INSERT INTO`,
+	// and the clause which follows the values. The suffix is empty if the dialect ignores by the keyword.
+	InsertIgnore() (insert string, suffix string, err error)
+
+	// OnDuplicateKeyUpdate returns the clause to update the duplicated record on insert, which follows the values
+	// and is followed by the assignments. The dialect which requires the conflict target returns an error.
+	OnDuplicateKeyUpdate() (string, error)
+
+	// OnConflictUpdate returns the clause to update the row which conflicts with the keys(conflict target) on insert,
+	// which is followed by the assignments. The keys are ignored in MySQL, which detects the conflict by all unique keys.
+	OnConflictUpdate(keys ...string) (string, error)
+
+	// BoolLiteral returns the literal of the boolean value
+	BoolLiteral(v bool) string
+
+	// ExplainPrefix returns the prefix to explain the statement
+	ExplainPrefix() string
+
+	// SelectOne returns the statement to select a constant without a table
+	SelectOne() string
+
+	// Truncate returns the statement to delete all records of the table
+	Truncate(tableExpr string) string
+
+	// SupportsRowValueComparison reports whether the row value comparison
+	// such as `(a, b) > (?, ?)` is available
+	SupportsRowValueComparison() bool
+
+	// SupportsMultiTableWrite reports whether UPDATE and DELETE can join other tables
+	// such as `UPDATE a JOIN b ON ... SET ...` and `DELETE a FROM a JOIN b ON ...`
+	SupportsMultiTableWrite() bool
+
+	// SupportsWriteOrderLimit reports whether UPDATE and DELETE accept ORDER BY and LIMIT
+	SupportsWriteOrderLimit() bool
+
+	// MaxPlaceholders returns the maximum number of placeholders in a statement, or 0 if there is no limit
+	MaxPlaceholders() int
+
+	// SupportsReturning reports whether INSERT, UPDATE and DELETE return the written rows by RETURNING
+	SupportsReturning() bool
+
+	// SetOperator returns the set operator(SetOperatorUnion and so on) to combine select statements
+	SetOperator(operator string) (string, error)
+
+	// GroupConcat returns the aggregate function to concatenate the values in the group.
+	// orderBy is the comma separated orders, which is empty if not specified.
+	GroupConcat(expr, separator, orderBy string) (string, error)
+
+	// Concat returns the expression to concatenate the strings
+	Concat(exprs ...string) string
+
+	// DateFormat returns the expression to format the date by the format of the dialect
+	DateFormat(expr, format string) (string, error)
+
+	// InsertedValue returns the reference to the quoted column of the row which is proposed for insertion
+	// in OnDuplicateKeyUpdate, such as VALUES(column) and excluded.column.
+	InsertedValue(column string) (string, error)
+}
+
+var (
+	mu       sync.RWMutex
+	dialects = make(map[string]Dialect)
+)
+
+// Register registers the dialect by its name
+//
+// The dialect which has same name is overwritten.
+func Register(d Dialect) {
+	mu.Lock()
+	defer mu.Unlock()
+	dialects[strings.ToLower(d.Name())] = d
+}
+
+// Get returns the dialect registered by the name
+func Get(name string) (Dialect, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	d, ok := dialects[strings.ToLower(name)]
+	return d, ok
+}
+
+// StatementType is the type of the statement returned by GetDialectStatements
+//
+// Deprecated: use the methods of Dialect instead.
 type StatementType int
 
 const (
@@ -9,8 +129,134 @@ const (
 	StatementTypeOnDuplicateKeyUpdate
 )
 
-var sqlDialect = make(map[string]map[StatementType]string)
+// GetDialectStatements returns the statements of the dialect registered by the name, or nil if it is not registered.
+// The statement which is not supported by the dialect is not contained.
+//
+// Deprecated: use Get and the methods of Dialect instead.
+func GetDialectStatements(name string) map[StatementType]string {
+	d, ok := Get(name)
+	if !ok {
+		return nil
+	}
+
+	statements := map[StatementType]string{
+		StatementTypeSelectOne: d.SelectOne(),
+	}
+	if insert, suffix, err := d.InsertIgnore(); err == nil {
+		if suffix != "" {
+			statements[StatementTypeOnDuplicateKeyIgnore] = suffix
+		} else {
+			statements[StatementTypeOnDuplicateKeyIgnore] = insert
+		}
+	}
+	if update, err := d.OnDuplicateKeyUpdate(); err == nil {
+		statements[StatementTypeOnDuplicateKeyUpdate] = update
+	}
+	return statements
+}
+
+// Standard is the dialect based on the SQL standard
+//
+// It is intended to be embedded to implement other dialects.
+type Standard struct {
+	DialectName string
+}
+
+func (d *Standard) Name() string {
+	return d.DialectName
+}
+
+func (d *Standard) QuoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func (d *Standard) Placeholder(int) string {
+	return "?"
+}
+
+func (d *Standard) LimitOffset(limit int32, offset int64) string {
+	if offset > 0 {
+		return fmt.Sprintf("LIMIT %d OFFSET %d", limit, offset)
+	}
+	return fmt.Sprintf("LIMIT %d", limit)
+}
+
+func (d *Standard) InsertIgnore() (string, string, error) {
+	return "", "", fmt.Errorf("%s : insert ignore is %w", d.Name(), ErrorNotSupported)
+}
+
+func (d *Standard) OnDuplicateKeyUpdate() (string, error) {
+	return "", fmt.Errorf("%s : on duplicate key update is %w", d.Name(), ErrorNotSupported)
+}
+
+func (d *Standard) OnConflictUpdate(...string) (string, error) {
+	return "", fmt.Errorf("%s : on conflict update is %w", d.Name(), ErrorNotSupported)
+}
+
+func (d *Standard) BoolLiteral(v bool) string {
+	if v {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+func (d *Standard) ExplainPrefix() string {
+	return "EXPLAIN"
+}
+
+func (d *Standard) SelectOne() string {
+	return "SELECT 1"
+}
+
+func (d *Standard) Truncate(tableExpr string) string {
+	return "TRUNCATE " + tableExpr
+}
+
+func (d *Standard) SupportsRowValueComparison() bool {
+	return false
+}
+
+func (d *Standard) SupportsMultiTableWrite() bool {
+	return false
+}
+
+func (d *Standard) SupportsWriteOrderLimit() bool {
+	return false
+}
+
+func (d *Standard) MaxPlaceholders() int {
+	return 0
+}
+
+func (d *Standard) SupportsReturning() bool {
+	return false
+}
+
+func (d *Standard) SetOperator(operator string) (string, error) {
+	switch operator {
+	case SetOperatorUnion, SetOperatorUnionAll, SetOperatorIntersect, SetOperatorExcept:
+		return operator, nil
+	}
+	return "", fmt.Errorf("%s : %s is %w", d.Name(), operator, ErrorNotSupported)
+}
+
+func (d *Standard) GroupConcat(string, string, string) (string, error) {
+	return "", fmt.Errorf("%s : group concat is %w", d.Name(), ErrorNotSupported)
+}
+
+func (d *Standard) Concat(exprs ...string) string {
+	return "(" + strings.Join(exprs, " || ") + ")"
+}
+
+func (d *Standard) DateFormat(string, string) (string, error) {
+	return "", fmt.Errorf("%s : date format is %w", d.Name(), ErrorNotSupported)
+}
+
+func (d *Standard) InsertedValue(string) (string, error) {
+	return "", fmt.Errorf("%s : inserted value is %w", d.Name(), ErrorNotSupported)
+}
 
-func GetDialectStatements(dialect string) map[StatementType]string {
-	return sqlDialect[dialect]
+// StringLiteral returns the string literal which is quoted by single quote
+func StringLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
 }
@@ -1,12 +1,84 @@
 package dialect
 
+import (
+	"fmt"
+	"strings"
+)
+
 const MySQL = "mysql"
 
 func init() {
-	sqlDialect[MySQL] =
-		map[StatementType]string{
-			StatementTypeSelectOne:            "SELECT 1 FROM dual",
-			StatementTypeOnDuplicateKeyIgnore: "ON DUPLICATE KEY IGNORE",
-			StatementTypeOnDuplicateKeyUpdate: "ON DUPLICATE KEY UPDATE",
-		}
+	Register(&mysqlDialect{Standard{DialectName: MySQL}})
+}
+
+type mysqlDialect struct {
+	Standard
+}
+
+func (d *mysqlDialect) QuoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+func (d *mysqlDialect) InsertIgnore() (string, string, error) {
+	return "INSERT IGNORE INTO", "", nil
+}
+
+func (d *mysqlDialect) OnDuplicateKeyUpdate() (string, error) {
+	return "ON DUPLICATE KEY UPDATE", nil
+}
+
+func (d *mysqlDialect) OnConflictUpdate(...string) (string, error) {
+	return d.OnDuplicateKeyUpdate()
+}
+
+func (d *mysqlDialect) SelectOne() string {
+	return "SELECT 1 FROM dual"
+}
+
+// MaxPlaceholders the number of parameters of the prepared statement is 2 bytes in the protocol
+func (d *mysqlDialect) MaxPlaceholders() int {
+	return 65535
+}
+
+func (d *mysqlDialect) SupportsRowValueComparison() bool {
+	return true
+}
+
+func (d *mysqlDialect) SupportsMultiTableWrite() bool {
+	return true
+}
+
+func (d *mysqlDialect) SupportsWriteOrderLimit() bool {
+	return true
+}
+
+// SetOperator INTERSECT and EXCEPT are not available until MySQL 8.0.31
+func (d *mysqlDialect) SetOperator(operator string) (string, error) {
+	switch operator {
+	case SetOperatorUnion, SetOperatorUnionAll:
+		return operator, nil
+	}
+	return "", fmt.Errorf("%s : %s is %w", d.Name(), operator, ErrorNotSupported)
+}
+
+func (d *mysqlDialect) GroupConcat(expr, separator, orderBy string) (string, error) {
+	if orderBy != "" {
+		expr += " ORDER BY " + orderBy
+	}
+	// MySQLの文字列リテラルではバックスラッシュもエスケープする
+	return fmt.Sprintf("GROUP_CONCAT(%s SEPARATOR %s)", expr, StringLiteral(strings.ReplaceAll(separator, `\`, `\\`))), nil
+}
+
+// Concat MySQLの || はPIPES_AS_CONCATが無効の場合は論理和となるため、CONCATを使う
+func (d *mysqlDialect) Concat(exprs ...string) string {
+	return "CONCAT(" + strings.Join(exprs, ", ") + ")"
+}
+
+func (d *mysqlDialect) DateFormat(expr, format string) (string, error) {
+	return fmt.Sprintf("DATE_FORMAT(%s, %s)", expr, StringLiteral(strings.ReplaceAll(format, `\`, `\\`))), nil
+}
+
+// InsertedValue VALUES(column) is deprecated since MySQL 8.0.20, but the row alias is not available until MySQL 8.0.19
+func (d *mysqlDialect) InsertedValue(column string) (string, error) {
+	return "VALUES(" + column + ")", nil
 }
@@ -0,0 +1,65 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+)
+
+const Postgres = "postgres"
+
+func init() {
+	Register(&postgresDialect{Standard{DialectName: Postgres}})
+}
+
+type postgresDialect struct {
+	Standard
+}
+
+func (d *postgresDialect) Placeholder(n int) string {
+	return fmt.Sprintf("$%d", n)
+}
+
+func (d *postgresDialect) InsertIgnore() (string, string, error) {
+	return "INSERT INTO", "ON CONFLICT DO NOTHING", nil
+}
+
+// OnDuplicateKeyUpdate DO UPDATE requires the conflict target, use OnConflictUpdate(keys) instead
+func (d *postgresDialect) OnDuplicateKeyUpdate() (string, error) {
+	return "", fmt.Errorf("%s : on duplicate key update without conflict target is %w, use OnConflictUpdate(keys) instead", d.Name(), ErrorNotSupported)
+}
+
+// OnConflictUpdate the conflict target is required for DO UPDATE
+func (d *postgresDialect) OnConflictUpdate(keys ...string) (string, error) {
+	if len(keys) == 0 {
+		return "", fmt.Errorf("%s : on conflict update without conflict target is %w", d.Name(), ErrorNotSupported)
+	}
+	return fmt.Sprintf("ON CONFLICT (%s) DO UPDATE SET", strings.Join(keys, ", ")), nil
+}
+
+func (d *postgresDialect) SupportsRowValueComparison() bool {
+	return true
+}
+
+// MaxPlaceholders the number of parameters of the Bind message is 2 bytes in the protocol
+func (d *postgresDialect) MaxPlaceholders() int {
+	return 65535
+}
+
+func (d *postgresDialect) SupportsReturning() bool {
+	return true
+}
+
+func (d *postgresDialect) GroupConcat(expr, separator, orderBy string) (string, error) {
+	if orderBy != "" {
+		orderBy = " ORDER BY " + orderBy
+	}
+	return fmt.Sprintf("STRING_AGG(CAST(%s AS TEXT), %s%s)", expr, StringLiteral(separator), orderBy), nil
+}
+
+func (d *postgresDialect) DateFormat(expr, format string) (string, error) {
+	return fmt.Sprintf("TO_CHAR(%s, %s)", expr, StringLiteral(format)), nil
+}
+
+func (d *postgresDialect) InsertedValue(column string) (string, error) {
+	return "excluded." + column, nil
+}
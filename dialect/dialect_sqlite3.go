@@ -1,10 +1,72 @@
 package dialect
 
-const Sqlite3 = "seqlite3"
+import (
+	"fmt"
+	"strings"
+)
+
+// Sqlite3 is same as the driver name of github.com/mattn/go-sqlite3
+const Sqlite3 = "sqlite3"
 
 func init() {
-	sqlDialect[Sqlite3] =
-		map[StatementType]string{
-			StatementTypeSelectOne: "SELECT 1",
-		}
+	Register(&sqlite3Dialect{Standard{DialectName: Sqlite3}})
+}
+
+// sqlite3Dialect requires SQLite 3.35.0+ for the upsert without the conflict target
+type sqlite3Dialect struct {
+	Standard
+}
+
+func (d *sqlite3Dialect) InsertIgnore() (string, string, error) {
+	return "INSERT OR IGNORE INTO", "", nil
+}
+
+func (d *sqlite3Dialect) OnDuplicateKeyUpdate() (string, error) {
+	return "ON CONFLICT DO UPDATE SET", nil
+}
+
+func (d *sqlite3Dialect) OnConflictUpdate(keys ...string) (string, error) {
+	if len(keys) == 0 {
+		return "ON CONFLICT DO UPDATE SET", nil
+	}
+	return fmt.Sprintf("ON CONFLICT (%s) DO UPDATE SET", strings.Join(keys, ", ")), nil
+}
+
+func (d *sqlite3Dialect) ExplainPrefix() string {
+	return "EXPLAIN QUERY PLAN"
+}
+
+// Truncate SQLite has no TRUNCATE statement, but DELETE without WHERE is optimized as truncate
+func (d *sqlite3Dialect) Truncate(tableExpr string) string {
+	return "DELETE FROM " + tableExpr
+}
+
+func (d *sqlite3Dialect) SupportsRowValueComparison() bool {
+	return true
+}
+
+// MaxPlaceholders SQLITE_MAX_VARIABLE_NUMBER is 32766 by default since SQLite 3.32.0
+func (d *sqlite3Dialect) MaxPlaceholders() int {
+	return 32766
+}
+
+// SupportsReturning RETURNING requires SQLite 3.35.0+ as well as the upsert
+func (d *sqlite3Dialect) SupportsReturning() bool {
+	return true
+}
+
+// GroupConcat ORDER BY in the aggregate function requires SQLite 3.44.0+, which is not supported
+func (d *sqlite3Dialect) GroupConcat(expr, separator, orderBy string) (string, error) {
+	if orderBy != "" {
+		return "", fmt.Errorf("%s : order by in group concat is %w", d.Name(), ErrorNotSupported)
+	}
+	return fmt.Sprintf("GROUP_CONCAT(%s, %s)", expr, StringLiteral(separator)), nil
+}
+
+func (d *sqlite3Dialect) DateFormat(expr, format string) (string, error) {
+	return fmt.Sprintf("STRFTIME(%s, %s)", StringLiteral(format), expr), nil
+}
+
+func (d *sqlite3Dialect) InsertedValue(column string) (string, error) {
+	return "excluded." + column, nil
 }
@@ -0,0 +1,120 @@
+package dialect
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type customDialect struct {
+	Standard
+}
+
+func (d *customDialect) Placeholder(n int) string {
+	return ":p" + string(rune('0'+n))
+}
+
+func TestRegister(t *testing.T) {
+	asserts := assert.New(t)
+
+	_, ok := Get("custom")
+	asserts.False(ok)
+
+	Register(&customDialect{Standard{DialectName: "Custom"}})
+
+	d, ok := Get("custom")
+	asserts.True(ok)
+	asserts.Equal("Custom", d.Name())
+	asserts.Equal(":p1", d.Placeholder(1))
+	asserts.Equal(`"t1"`, d.QuoteIdentifier("t1"))
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	tests := []struct {
+		dialect string
+		name    string
+		expect  string
+	}{
+		{dialect: MySQL, name: "t1", expect: "`t1`"},
+		{dialect: MySQL, name: "t`1", expect: "`t``1`"},
+		{dialect: Postgres, name: "t1", expect: `"t1"`},
+		{dialect: Postgres, name: `t"1`, expect: `"t""1"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.dialect+"/"+test.name, func(t *testing.T) {
+			d, ok := Get(test.dialect)
+			if assert.True(t, ok) {
+				assert.Equal(t, test.expect, d.QuoteIdentifier(test.name))
+			}
+		})
+	}
+}
+
+func TestLimitOffset(t *testing.T) {
+	d, _ := Get(MySQL)
+
+	asserts := assert.New(t)
+	asserts.Equal("LIMIT 10", d.LimitOffset(10, 0))
+	asserts.Equal("LIMIT 10 OFFSET 5", d.LimitOffset(10, 5))
+}
+
+func TestOnDuplicateKeyUpdate(t *testing.T) {
+	asserts := assert.New(t)
+
+	mysql, _ := Get(MySQL)
+	clause, err := mysql.OnDuplicateKeyUpdate()
+	asserts.Nil(err)
+	asserts.Equal("ON DUPLICATE KEY UPDATE", clause)
+	value, err := mysql.InsertedValue("`c1`")
+	asserts.Nil(err)
+	asserts.Equal("VALUES(`c1`)", value)
+
+	// PostgreSQLはDO UPDATEに競合対象が必要
+	postgres, _ := Get(Postgres)
+	_, err = postgres.OnDuplicateKeyUpdate()
+	asserts.True(errors.Is(err, ErrorNotSupported))
+	clause, err = postgres.OnConflictUpdate(`"id"`)
+	asserts.Nil(err)
+	asserts.Equal(`ON CONFLICT ("id") DO UPDATE SET`, clause)
+}
+
+func TestSetOperator(t *testing.T) {
+	tests := []struct {
+		dialect   string
+		operator  string
+		supported bool
+	}{
+		{dialect: MySQL, operator: SetOperatorUnion, supported: true},
+		{dialect: MySQL, operator: SetOperatorUnionAll, supported: true},
+		{dialect: MySQL, operator: SetOperatorIntersect, supported: false},
+		{dialect: MySQL, operator: SetOperatorExcept, supported: false},
+		{dialect: Postgres, operator: SetOperatorIntersect, supported: true},
+		{dialect: Sqlite3, operator: SetOperatorExcept, supported: true},
+		{dialect: Sqlite3, operator: "INTERSECT ALL", supported: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.dialect+"/"+test.operator, func(t *testing.T) {
+			d, _ := Get(test.dialect)
+
+			op, err := d.SetOperator(test.operator)
+			if test.supported {
+				assert.Nil(t, err)
+				assert.Equal(t, test.operator, op)
+			} else {
+				assert.True(t, errors.Is(err, ErrorNotSupported))
+			}
+		})
+	}
+}
+
+func TestGetDialectStatements(t *testing.T) {
+	asserts := assert.New(t)
+
+	sqlite3, _ := Get(Sqlite3)
+	statements := GetDialectStatements(Sqlite3)
+	asserts.Equal(sqlite3.SelectOne(), statements[StatementTypeSelectOne])
+	asserts.Equal("INSERT OR IGNORE INTO", statements[StatementTypeOnDuplicateKeyIgnore])
+	asserts.Nil(GetDialectStatements("unknown"))
+}
@@ -5,7 +5,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"github.com/tmarcus87/sqlike/logger"
 	"github.com/tmarcus87/sqlike/session"
+	"time"
 )
 
 type contextKey int
@@ -16,8 +18,9 @@ const (
 )
 
 var (
-	ErrCtxCastFail  = errors.New("failed to cast ctx value")
-	ErrNoTx         = errors.New("not tx found")
+	ErrCtxCastFail = errors.New("failed to cast ctx value")
+	ErrNoTx        = errors.New("not tx found")
+	// Deprecated: BeginTx no longer returns this error. Nested BeginTx creates SAVEPOINT instead.
 	ErrAlreadyBegan = errors.New("tx is already began")
 )
 
@@ -31,22 +34,39 @@ type Engine interface {
 
 	// Begin transaction
 	//
-	// Begin transaction and set to context
-	BeginTx(ctx context.Context) (context.Context, error)
+	// Begin transaction and set to context.
+	// The transaction is rolled back when the context is done.
+	// Read-only transaction is began on the replica DB unless the executed flag is set in Context.
+	// If the transaction is already began in Context, SAVEPOINT is created as nested transaction
+	// and opts are ignored.
+	BeginTx(ctx context.Context, opts ...TxOption) (context.Context, error)
+
+	// Run function in transaction
+	//
+	// Begin transaction and run fn with the context which has the transaction.
+	// The transaction is committed when fn returns nil, and rolled back when fn returns an error or panics.
+	// When WithRetry is specified, whole of fn is retried on the retryable error
+	// (deadlock or lock wait timeout of MySQL by default).
+	// If the transaction is already began in Context, fn runs in the nested transaction(SAVEPOINT)
+	// and is never retried because the error such as deadlock aborts the outermost transaction.
+	RunInTx(ctx context.Context, fn func(ctx context.Context) error, opts ...TxOption) error
 
 	// Rollback transaction
 	//
-	// Rollback transaction and delete from context
+	// Rollback transaction and delete from context.
+	// For nested transaction, rollback to SAVEPOINT and restore the outer transaction to context.
 	RollbackTx(ctx context.Context) (context.Context, error)
 
 	// Commit transaction
 	//
-	// Commit transaction and delete  from context
+	// Commit transaction and delete  from context.
+	// For nested transaction, release SAVEPOINT and restore the outer transaction to context.
 	CommitTx(ctx context.Context) (context.Context, error)
 
 	// Close transaction
 	//
 	// Close transaction(uncommited transaction will be rollbacked).
+	// For nested transaction, the outer transaction is restored to context.
 	CloseTx(ctx context.Context) (context.Context, error)
 
 	// Get session from context
@@ -64,6 +84,7 @@ type basicEngine struct {
 	master       *sql.DB
 	slaves       []*sql.DB
 	slaveHandler SlaveSelectionHandler
+	sessionOpts  []session.Option
 }
 
 func (e *basicEngine) NewSession(ctx context.Context) session.Session {
@@ -73,12 +94,31 @@ func (e *basicEngine) NewSession(ctx context.Context) session.Session {
 	return e.newSlaveSession(ctx)
 }
 
-func (e *basicEngine) BeginTx(ctx context.Context) (context.Context, error) {
+func (e *basicEngine) BeginTx(ctx context.Context, opts ...TxOption) (context.Context, error) {
 	if ctx.Value(txKey) != nil {
-		return ctx, ErrAlreadyBegan
+		// ネストしたトランザクションはSAVEPOINTとして扱う
+		txs, err := e.GetTxSession(ctx)
+		if err != nil {
+			return ctx, err
+		}
+		nested, err := txs.Savepoint()
+		if err != nil {
+			return ctx, err
+		}
+		return context.WithValue(ctx, txKey, nested), nil
+	}
+
+	o := newTxOptions(opts...)
+
+	if o.ReadOnly {
+		txs, err := e.NewSession(ctx).BeginTx(&o.TxOptions)
+		if err != nil {
+			return ctx, err
+		}
+		return context.WithValue(ctx, txKey, txs), nil
 	}
 
-	txs, err := e.newMasterSession(ctx).Begin()
+	txs, err := e.newMasterSession(ctx).BeginTx(&o.TxOptions)
 	if err != nil {
 		return ctx, err
 	}
@@ -86,6 +126,64 @@ func (e *basicEngine) BeginTx(ctx context.Context) (context.Context, error) {
 	return MarkAsExecuted(context.WithValue(ctx, txKey, txs)), nil
 }
 
+func (e *basicEngine) RunInTx(ctx context.Context, fn func(ctx context.Context) error, opts ...TxOption) error {
+	o := newTxOptions(opts...)
+
+	// ネストしたトランザクションはリトライせず外側のトランザクションに委ねる
+	if ctx.Value(txKey) != nil {
+		o.MaxRetries = 0
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := e.runInTx(ctx, fn, opts...)
+		if err == nil {
+			return nil
+		}
+		if attempt >= o.MaxRetries || !o.RetryIf(err) {
+			return err
+		}
+
+		logger.Info("Retry transaction(%d/%d) : %v", attempt+1, o.MaxRetries, err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(o.Backoff(attempt)):
+		}
+	}
+}
+
+func (e *basicEngine) runInTx(ctx context.Context, fn func(ctx context.Context) error, opts ...TxOption) error {
+	txCtx, err := e.BeginTx(ctx, opts...)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			if _, rerr := e.CloseTx(txCtx); rerr != nil {
+				logger.Warn("failed to rollback tx : %v", rerr)
+			}
+			panic(p)
+		}
+	}()
+
+	if err := fn(txCtx); err != nil {
+		if _, rerr := e.CloseTx(txCtx); rerr != nil {
+			logger.Warn("failed to rollback tx : %v", rerr)
+		}
+		return err
+	}
+
+	if _, err := e.CommitTx(txCtx); err != nil {
+		if _, rerr := e.CloseTx(txCtx); rerr != nil {
+			logger.Warn("failed to rollback tx : %v", rerr)
+		}
+		return err
+	}
+	return nil
+}
+
 func (e *basicEngine) RollbackTx(ctx context.Context) (context.Context, error) {
 	txs, err := e.GetTxSession(ctx)
 	if err != nil {
@@ -98,7 +196,7 @@ func (e *basicEngine) RollbackTx(ctx context.Context) (context.Context, error) {
 	}
 
 	// Contextを更新
-	return context.WithValue(ctx, txKey, nil), nil
+	return withParentTx(ctx, txs), nil
 }
 
 func (e *basicEngine) CommitTx(ctx context.Context) (context.Context, error) {
@@ -113,7 +211,7 @@ func (e *basicEngine) CommitTx(ctx context.Context) (context.Context, error) {
 	}
 
 	// Contextを更新
-	return context.WithValue(ctx, txKey, nil), nil
+	return withParentTx(ctx, txs), nil
 }
 
 func (e *basicEngine) CloseTx(ctx context.Context) (context.Context, error) {
@@ -122,13 +220,13 @@ func (e *basicEngine) CloseTx(ctx context.Context) (context.Context, error) {
 		return ctx, err
 	}
 
-	// Commit
+	// Close
 	if err = txs.Close(); err != nil {
 		return ctx, err
 	}
 
 	// Contextを更新
-	return context.WithValue(ctx, txKey, nil), nil
+	return withParentTx(ctx, txs), nil
 }
 
 func (e *basicEngine) GetSession(ctx context.Context) (session.SQLSession, bool, error) {
@@ -162,8 +260,16 @@ func (e *basicEngine) GetTxSession(ctx context.Context) (session.TxSession, erro
 
 }
 
+// withParentTx ネストしたトランザクションの場合は外側のトランザクションをContextに戻す
+func withParentTx(ctx context.Context, txs session.TxSession) context.Context {
+	if parent := txs.Parent(); parent != nil {
+		return context.WithValue(ctx, txKey, parent)
+	}
+	return context.WithValue(ctx, txKey, nil)
+}
+
 func (e *basicEngine) newMasterSession(ctx context.Context) session.Session {
-	return session.NewSession(ctx, e.master, e.dialect, false)
+	return session.NewSession(ctx, e.master, e.dialect, false, e.sessionOpts...)
 }
 
 func (e *basicEngine) newSlaveSession(ctx context.Context) session.Session {
@@ -173,7 +279,7 @@ func (e *basicEngine) newSlaveSession(ctx context.Context) session.Session {
 	} else {
 		slave = e.master
 	}
-	return session.NewSession(ctx, slave, e.dialect, true)
+	return session.NewSession(ctx, slave, e.dialect, true, e.sessionOpts...)
 }
 
 func (e *basicEngine) Close() error {
@@ -0,0 +1,297 @@
+package sqlike
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/go-sql-driver/mysql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/tmarcus87/sqlike/dialect"
+	"github.com/tmarcus87/sqlike/model"
+	"testing"
+	"time"
+)
+
+var (
+	authorTable      = model.NewTable("author")
+	authorNameColumn = model.NewTextColumn(authorTable, "name")
+)
+
+// newTestEngine creates the engine which connects to in-memory SQLite database
+func newTestEngine(t *testing.T) Engine {
+	db, err := sql.Open(dialect.Sqlite3, ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// in-memoryのDBはコネクション毎に作られるため、コネクションを1つに制限する
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec("CREATE TABLE author (id INTEGER PRIMARY KEY AUTOINCREMENT, name VARCHAR(300))"); err != nil {
+		t.Fatal(err)
+	}
+
+	return &basicEngine{
+		dialect:      dialect.Sqlite3,
+		master:       db,
+		slaves:       make([]*sql.DB, 0),
+		slaveHandler: RoundRobbinSelectionHandler(),
+	}
+}
+
+func countAuthors(t *testing.T, e Engine) int {
+	rows, err := e.NewSession(MarkAsExecuted(context.Background())).SelectFrom(authorTable).Build().FetchMap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return len(rows)
+}
+
+func TestEngine_BeginTx(t *testing.T) {
+	e := newTestEngine(t)
+	defer e.Close()
+
+	t.Run("Commit", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		ctx, err := e.BeginTx(context.Background(), WithIsolationLevel(sql.LevelSerializable))
+		asserts.Nil(err)
+		asserts.True(isExecuted(ctx))
+
+		txs, err := e.GetTxSession(ctx)
+		asserts.Nil(err)
+		asserts.Nil(txs.InsertInto(authorTable).Columns(authorNameColumn).Values("William Shakespeare").Build().Execute().Error())
+
+		ctx, err = e.CommitTx(ctx)
+		asserts.Nil(err)
+		asserts.Equal(1, countAuthors(t, e))
+	})
+
+	t.Run("Nested", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		countInTx := func(ctx context.Context) int {
+			txs, err := e.GetTxSession(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rows, err := txs.SelectFrom(authorTable).Build().FetchMap()
+			if err != nil {
+				t.Fatal(err)
+			}
+			return len(rows)
+		}
+		insert := func(ctx context.Context, name string) {
+			txs, err := e.GetTxSession(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			asserts.Nil(txs.InsertInto(authorTable).Columns(authorNameColumn).Values(name).Build().Execute().Error())
+		}
+
+		outer, err := e.BeginTx(context.Background())
+		asserts.Nil(err)
+		insert(outer, "Jane Austen")
+
+		// Rollback inner tx
+		inner, err := e.BeginTx(outer)
+		asserts.Nil(err)
+		insert(inner, "Charles Dickens")
+		asserts.Equal(3, countInTx(inner))
+
+		restored, err := e.RollbackTx(inner)
+		asserts.Nil(err)
+		asserts.Equal(2, countInTx(restored))
+
+		// Commit inner tx
+		inner, err = e.BeginTx(restored)
+		asserts.Nil(err)
+		insert(inner, "Mark Twain")
+
+		restored, err = e.CommitTx(inner)
+		asserts.Nil(err)
+		asserts.Equal(3, countInTx(restored))
+
+		outerTxs, err := e.GetTxSession(restored)
+		asserts.Nil(err)
+		asserts.Equal(0, outerTxs.Depth())
+
+		_, err = e.CommitTx(restored)
+		asserts.Nil(err)
+		asserts.Equal(3, countAuthors(t, e))
+	})
+
+	t.Run("ReadOnly", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		ctx, err := e.BeginTx(context.Background(), WithReadOnly(), WithIsolationLevel(sql.LevelRepeatableRead))
+		asserts.Nil(err)
+		asserts.False(isExecuted(ctx))
+
+		sess, isTx, err := e.GetSession(ctx)
+		asserts.Nil(err)
+		asserts.True(isTx)
+
+		rows, err := sess.SelectFrom(authorTable).Build().FetchMap()
+		asserts.Nil(err)
+		asserts.Len(rows, 3)
+
+		_, err = e.CloseTx(ctx)
+		asserts.Nil(err)
+	})
+
+	t.Run("CanceledContext", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := e.BeginTx(ctx)
+		assert.Equal(t, context.Canceled, err)
+	})
+}
+
+func TestEngine_RunInTx(t *testing.T) {
+	e := newTestEngine(t)
+	defer e.Close()
+
+	insert := func(ctx context.Context) error {
+		txs, err := e.GetTxSession(ctx)
+		if err != nil {
+			return err
+		}
+		return txs.InsertInto(authorTable).Columns(authorNameColumn).Values("William Shakespeare").Build().Execute().Error()
+	}
+
+	t.Run("Commit", func(t *testing.T) {
+		asserts := assert.New(t)
+		asserts.Nil(e.RunInTx(context.Background(), insert))
+		asserts.Equal(1, countAuthors(t, e))
+	})
+
+	t.Run("RollbackOnError", func(t *testing.T) {
+		errFailed := errors.New("failed")
+
+		asserts := assert.New(t)
+		err := e.RunInTx(context.Background(), func(ctx context.Context) error {
+			if err := insert(ctx); err != nil {
+				return err
+			}
+			return errFailed
+		})
+		asserts.Equal(errFailed, err)
+		asserts.Equal(1, countAuthors(t, e))
+	})
+
+	t.Run("RollbackOnPanic", func(t *testing.T) {
+		asserts := assert.New(t)
+		asserts.PanicsWithValue("failed", func() {
+			_ = e.RunInTx(context.Background(), func(ctx context.Context) error {
+				if err := insert(ctx); err != nil {
+					return err
+				}
+				panic("failed")
+			})
+		})
+		asserts.Equal(1, countAuthors(t, e))
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		errRetryable := errors.New("retryable")
+
+		asserts := assert.New(t)
+		attempts := 0
+		err := e.RunInTx(context.Background(), func(ctx context.Context) error {
+			attempts++
+			if err := insert(ctx); err != nil {
+				return err
+			}
+			if attempts < 3 {
+				return errRetryable
+			}
+			return nil
+		},
+			WithRetry(3, time.Millisecond),
+			WithRetryIf(func(err error) bool { return err == errRetryable }))
+		asserts.Nil(err)
+		asserts.Equal(3, attempts)
+		asserts.Equal(2, countAuthors(t, e))
+	})
+
+	t.Run("RetryExceeded", func(t *testing.T) {
+		deadlock := &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"}
+
+		asserts := assert.New(t)
+		attempts := 0
+		err := e.RunInTx(context.Background(), func(ctx context.Context) error {
+			attempts++
+			return fmt.Errorf("failed to update : %w", deadlock)
+		}, WithRetry(2, time.Millisecond))
+		asserts.True(errors.Is(err, deadlock))
+		asserts.Equal(3, attempts)
+	})
+
+	t.Run("NotRetryable", func(t *testing.T) {
+		asserts := assert.New(t)
+		attempts := 0
+		err := e.RunInTx(context.Background(), func(ctx context.Context) error {
+			attempts++
+			return &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}
+		}, WithRetry(2, time.Millisecond))
+		asserts.NotNil(err)
+		asserts.Equal(1, attempts)
+	})
+}
+
+func TestEngine_RunInTx_Nested(t *testing.T) {
+	e := newTestEngine(t)
+	defer e.Close()
+
+	asserts := assert.New(t)
+
+	insert := func(name string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			txs, err := e.GetTxSession(ctx)
+			if err != nil {
+				return err
+			}
+			return txs.InsertInto(authorTable).Columns(authorNameColumn).Values(name).Build().Execute().Error()
+		}
+	}
+
+	errFailed := errors.New("failed")
+	innerAttempts := 0
+
+	err := e.RunInTx(context.Background(), func(ctx context.Context) error {
+		if err := insert("Jane Austen")(ctx); err != nil {
+			return err
+		}
+
+		// Inner tx is rolled back to SAVEPOINT and never retried
+		err := e.RunInTx(ctx, func(ctx context.Context) error {
+			innerAttempts++
+			txs, err := e.GetTxSession(ctx)
+			if err != nil {
+				return err
+			}
+			asserts.Equal(1, txs.Depth())
+			if err := insert("Charles Dickens")(ctx); err != nil {
+				return err
+			}
+			return errFailed
+		}, WithRetry(3, time.Millisecond), WithRetryIf(func(err error) bool { return true }))
+		asserts.Equal(errFailed, err)
+
+		return e.RunInTx(ctx, insert("Mark Twain"))
+	})
+	asserts.Nil(err)
+	asserts.Equal(1, innerAttempts)
+	asserts.Equal(2, countAuthors(t, e))
+}
+
+func TestIsRetryableError(t *testing.T) {
+	asserts := assert.New(t)
+	asserts.True(IsRetryableError(&mysql.MySQLError{Number: 1213}))
+	asserts.True(IsRetryableError(fmt.Errorf("wrapped : %w", &mysql.MySQLError{Number: 1205})))
+	asserts.False(IsRetryableError(&mysql.MySQLError{Number: 1062}))
+	asserts.False(IsRetryableError(errors.New("error")))
+}
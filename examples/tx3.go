@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/tmarcus87/sqlike"
+	"time"
+)
+
+func init() {
+	examples["tx3"] = tx3
+}
+
+func tx3(e sqlike.Engine) error {
+	// Truncate table
+	if err := e.NewSession(sqlike.MarkAsExecuted(context.Background())).Truncate(bookTable).Build().Execute().Error(); err != nil {
+		return fmt.Errorf("failed to truncate : %w", err)
+	}
+
+	// Insert w/ tx, which is committed when the function returns nil
+	if err := e.RunInTx(context.Background(), func(ctx context.Context) error {
+		sess, err := e.GetTxSession(ctx)
+		if err != nil {
+			return err
+		}
+		return sess.InsertInto(bookTable).
+			Columns(bookTitleColumn, bookAuthorIdColumn).
+			Values("foo", 1).
+			Build().
+			Execute().
+			Error()
+	}, sqlike.WithRetry(3, 10*time.Millisecond)); err != nil {
+		return fmt.Errorf("failed to insert records : %w", err)
+	}
+
+	// Insert w/ tx, which is rolled back when the function returns an error
+	errCanceled := errors.New("canceled")
+	if err := e.RunInTx(context.Background(), func(ctx context.Context) error {
+		sess, err := e.GetTxSession(ctx)
+		if err != nil {
+			return err
+		}
+		if err := sess.InsertInto(bookTable).
+			Columns(bookTitleColumn, bookAuthorIdColumn).
+			Values("bar", 1).
+			Build().
+			Execute().
+			Error(); err != nil {
+			return err
+		}
+		return errCanceled
+	}); !errors.Is(err, errCanceled) {
+		return fmt.Errorf("unexpected error : %w", err)
+	}
+
+	// Check from another session
+	{
+		records, err := e.NewSession(sqlike.MarkAsExecuted(context.Background())).SelectFrom(bookTable).Build().FetchMap()
+		if err != nil {
+			return fmt.Errorf("failed to query : %w", err)
+		}
+		if len(records) != 1 {
+			return fmt.Errorf("unexpected number of rows : %d", len(records))
+		}
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ go 1.13
 require (
 	github.com/go-sql-driver/mysql v1.5.0
 	github.com/iancoleman/strcase v0.0.0-20191112232945-16388991a334
+	github.com/mattn/go-sqlite3 v1.14.17
 	github.com/stretchr/testify v1.5.1
 	gopkg.in/yaml.v2 v2.2.2
 )
@@ -1,7 +1,7 @@
 package model
 
 import (
-	"fmt"
+	"github.com/tmarcus87/sqlike/dialect"
 	"strings"
 )
 
@@ -37,24 +37,95 @@ type ColumnValue interface {
 	ColumnValue() interface{}
 }
 
-func calcExpr(c Column, cExpr, nExpr string) string {
-	fn := fmt.Sprintf("`%s`.`%s`", c.Table().SQLikeAliasOrName(), c.ColumnName())
-	if cExpr == "" {
-		return strings.ReplaceAll(nExpr, "$$", fn)
+// Expression is the Column which is rendered as the expression such as the aggregate function and the scalar function
+// instead of the column name in the conditions and the orders
+type Expression interface {
+	Column
+
+	// SQLikeExpr returns the expression without the alias for the dialect, and appends its values to bindings
+	SQLikeExpr(d dialect.Dialect, bindings *[]interface{}) (string, error)
+}
+
+// DialectField is the ColumnField which is rendered for the dialect of the statement
+type DialectField interface {
+	ColumnField
+
+	// SQLikeFieldExpr returns the field expression with the alias for the dialect, and appends its values to bindings
+	SQLikeFieldExpr(d dialect.Dialect, bindings *[]interface{}) (string, error)
+}
+
+// ColumnExpr returns the column or the expression which is used in the conditions and the orders
+func ColumnExpr(d dialect.Dialect, c Column, bindings *[]interface{}) (string, error) {
+	if e, ok := c.(Expression); ok {
+		return e.SQLikeExpr(d, bindings)
+	}
+	return quotedColumn(d, c), nil
+}
+
+// quotedColumn テーブル名(エイリアス名)で修飾したカラム名を返します
+func quotedColumn(d dialect.Dialect, c Column) string {
+	return d.QuoteIdentifier(c.Table().SQLikeAliasOrName()) + "." + d.QuoteIdentifier(c.ColumnName())
+}
+
+// bind 値をバインド変数に追加して、そのプレースホルダを返します
+func bind(d dialect.Dialect, bindings *[]interface{}, v interface{}) string {
+	*bindings = append(*bindings, v)
+	return d.Placeholder(len(*bindings))
+}
+
+// aliasExpr エイリアス名が指定されている場合は式にエイリアスを付けます
+func aliasExpr(d dialect.Dialect, expr, alias string) string {
+	if alias == "" {
+		return expr
 	}
-	return strings.ReplaceAll(nExpr, "$$", "("+cExpr+")")
+	return expr + " AS " + d.QuoteIdentifier(alias)
 }
 
-func fieldExpr(c Column, alias, expr string) string {
-	fn := fmt.Sprintf("`%s`.`%s`", c.Table().SQLikeAliasOrName(), c.ColumnName())
+// FieldExpr returns the field expression for the dialect, FieldExpr of the field is used if it is not a DialectField
+func FieldExpr(d dialect.Dialect, f ColumnField, bindings *[]interface{}) (string, error) {
+	if df, ok := f.(DialectField); ok {
+		return df.SQLikeFieldExpr(d, bindings)
+	}
+	return f.FieldExpr(), nil
+}
+
+// compatDialect FieldExprなどdialectを受け取らないメソッドは、従来通りMySQLの形式で出力します
+func compatDialect() dialect.Dialect {
+	d, _ := dialect.Get(dialect.MySQL)
+	return d
+}
+
+// compatFieldExpr FieldExprの実装。バインド変数を持つ式の値は失われます
+func compatFieldExpr(f DialectField) string {
+	bindings := make([]interface{}, 0)
+	expr, _ := f.SQLikeFieldExpr(compatDialect(), &bindings)
+	return expr
+}
+
+// aggregateExpr 集約関数が指定されている場合は式を集約関数で囲みます
+func aggregateExpr(expr, aggregate string) string {
+	if aggregate == "" {
+		return expr
+	}
 	if expr == "" {
 		expr = "$$"
 	}
-	expr = strings.ReplaceAll(expr, "$$", fn)
-	if alias != "" {
-		return fmt.Sprintf("%s AS `%s`", expr, alias)
+	return aggregate + "(" + expr + ")"
+}
+
+// conditionExpr 集約関数が指定されている場合は集約関数の式を、そうでなければカラムを返します
+func conditionExpr(d dialect.Dialect, c Column, expr, aggregate string) string {
+	if aggregate == "" {
+		return fieldExpr(d, c, "", "")
 	}
-	return expr
+	return fieldExpr(d, c, "", aggregateExpr(expr, aggregate))
+}
+
+func fieldExpr(d dialect.Dialect, c Column, alias, expr string) string {
+	if expr == "" {
+		expr = "$$"
+	}
+	return aliasExpr(d, strings.ReplaceAll(expr, "$$", quotedColumn(d, c)), alias)
 }
 
 func NewAllColumnField() ColumnField {
@@ -88,8 +159,16 @@ func (a *AllColumn) As(string) ColumnField {
 }
 
 func (a *AllColumn) FieldExpr() string {
+	return compatFieldExpr(a)
+}
+
+func (a *AllColumn) SQLikeFieldExpr(d dialect.Dialect, bindings *[]interface{}) (string, error) {
+	return a.SQLikeExpr(d, bindings)
+}
+
+func (a *AllColumn) SQLikeExpr(d dialect.Dialect, _ *[]interface{}) (string, error) {
 	if a.table == nil {
-		return "*"
+		return "*", nil
 	}
-	return fmt.Sprintf("`%s`.*", a.table.SQLikeAliasOrName())
+	return d.QuoteIdentifier(a.table.SQLikeAliasOrName()) + ".*", nil
 }
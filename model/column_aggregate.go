@@ -0,0 +1,339 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"github.com/tmarcus87/sqlike/dialect"
+)
+
+var (
+	ErrorNestedAggregate = errors.New("aggregate functions can not be nested")
+)
+
+// Sum returns SUM(field), use the method of the column such as Int64Column.Sum for the typed comparisons
+func Sum(field ColumnField) *AggregateColumnModifier {
+	return aggregate(field, "SUM")
+}
+
+// Min returns MIN(field), use the method of the column such as TextColumn.Min for the typed comparisons
+func Min(field ColumnField) *AggregateColumnModifier {
+	return aggregate(field, "MIN")
+}
+
+// Max returns MAX(field), use the method of the column such as TextColumn.Max for the typed comparisons
+func Max(field ColumnField) *AggregateColumnModifier {
+	return aggregate(field, "MAX")
+}
+
+// Avg returns AVG(field) as Float64Column
+func Avg(field ColumnField) *Float64Column {
+	if n, ok := field.(interface{ number() *NumberColumn }); ok {
+		return n.number().Avg()
+	}
+	return &Float64Column{NumberColumn: NumberColumn{
+		table:     field.Table(),
+		name:      field.ColumnName(),
+		operand:   field,
+		aggregate: "AVG",
+		err:       nestedAggregateError(field, "AVG"),
+	}}
+}
+
+// aggregatable is the column which keeps its type when wrapped by the aggregate function
+type aggregatable interface {
+	aggregated(fn string) ColumnField
+}
+
+func aggregate(field ColumnField, fn string) *AggregateColumnModifier {
+	return &AggregateColumnModifier{
+		column: field,
+		fn:     fn,
+		err:    nestedAggregateError(field, fn),
+	}
+}
+
+// AggregateColumnModifier is the aggregate function for any field, which is rendered by the type of the field.
+// The values of the comparisons are bound as they are.
+type AggregateColumnModifier struct {
+	column ColumnField
+	fn     string
+	alias  string
+	err    error
+}
+
+func (c *AggregateColumnModifier) Table() Table {
+	return c.column.Table()
+}
+
+func (c *AggregateColumnModifier) ColumnName() string {
+	return c.column.ColumnName()
+}
+
+func (c *AggregateColumnModifier) AliasOrName() string {
+	if c.alias != "" {
+		return c.alias
+	}
+	return c.column.AliasOrName()
+}
+
+func (c *AggregateColumnModifier) As(alias string) ColumnField {
+	c.alias = alias
+	return c
+}
+
+func (c *AggregateColumnModifier) FieldExpr() string {
+	return compatFieldExpr(c)
+}
+
+func (c *AggregateColumnModifier) SQLikeFieldExpr(d dialect.Dialect, bindings *[]interface{}) (string, error) {
+	expr, err := c.SQLikeExpr(d, bindings)
+	if err != nil {
+		return "", err
+	}
+	return aliasExpr(d, expr, c.alias), nil
+}
+
+// SQLikeExpr 型を保持するカラムは集約関数を指定したカラムとして、それ以外はエイリアスを除いた式を集約関数で囲みます
+func (c *AggregateColumnModifier) SQLikeExpr(d dialect.Dialect, bindings *[]interface{}) (string, error) {
+	if c.err != nil {
+		return "", c.err
+	}
+	if a, ok := c.column.(aggregatable); ok {
+		return ColumnExpr(d, a.aggregated(c.fn), bindings)
+	}
+	expr, err := ColumnExpr(d, c.column, bindings)
+	if err != nil {
+		return "", err
+	}
+	return c.fn + "(" + expr + ")", nil
+}
+
+func (c *AggregateColumnModifier) isAggregate() bool {
+	return true
+}
+
+func (c *AggregateColumnModifier) Eq(v interface{}) Condition {
+	return &SingleValueCondition{Column: c, Operator: "=", Value: v}
+}
+
+func (c *AggregateColumnModifier) NotEq(v interface{}) Condition {
+	return &SingleValueCondition{Column: c, Operator: "!=", Value: v}
+}
+
+func (c *AggregateColumnModifier) Gt(v interface{}) Condition {
+	return &SingleValueCondition{Column: c, Operator: ">", Value: v}
+}
+
+func (c *AggregateColumnModifier) GtOrEq(v interface{}) Condition {
+	return &SingleValueCondition{Column: c, Operator: ">=", Value: v}
+}
+
+func (c *AggregateColumnModifier) Lt(v interface{}) Condition {
+	return &SingleValueCondition{Column: c, Operator: "<", Value: v}
+}
+
+func (c *AggregateColumnModifier) LtOrEq(v interface{}) Condition {
+	return &SingleValueCondition{Column: c, Operator: "<=", Value: v}
+}
+
+func (c *AggregateColumnModifier) Asc() *SortOrder {
+	return &SortOrder{
+		Column: c,
+		Order:  OrderAsc,
+	}
+}
+
+func (c *AggregateColumnModifier) Desc() *SortOrder {
+	return &SortOrder{
+		Column: c,
+		Order:  OrderDesc,
+	}
+}
+
+// aggregateField is the field which is the result of the aggregate function
+type aggregateField interface {
+	isAggregate() bool
+}
+
+// nestedAggregateError 集約関数の結果を更に集約関数で囲む場合はエラーを返します
+func nestedAggregateError(field ColumnField, fn string) error {
+	if a, ok := field.(aggregateField); ok && a.isAggregate() {
+		return fmt.Errorf("%w : %s of '%s'", ErrorNestedAggregate, fn, field.ColumnName())
+	}
+	return nil
+}
+
+// withAggregate 集約関数で囲んだカラムのコピーを返します
+func (c *NumberColumn) withAggregate(fn string) NumberColumn {
+	cp := *c
+	cp.alias = ""
+	cp.aggregate = fn
+	if cp.err == nil {
+		cp.err = nestedAggregateError(c, fn)
+	}
+	return cp
+}
+
+func (c *NumberColumn) isAggregate() bool {
+	return c.aggregate != ""
+}
+
+func (c *NumberColumn) number() *NumberColumn {
+	return c
+}
+
+func (c *NumberColumn) Avg() *Float64Column {
+	return &Float64Column{NumberColumn: c.withAggregate("AVG")}
+}
+
+func (c *Int8Column) aggregated(fn string) ColumnField {
+	cp := *c
+	cp.NumberColumn = c.withAggregate(fn)
+	return &cp
+}
+
+func (c *Int8Column) Sum() *Int8Column {
+	return c.aggregated("SUM").(*Int8Column)
+}
+
+func (c *Int8Column) Min() *Int8Column {
+	return c.aggregated("MIN").(*Int8Column)
+}
+
+func (c *Int8Column) Max() *Int8Column {
+	return c.aggregated("MAX").(*Int8Column)
+}
+
+func (c *Int16Column) aggregated(fn string) ColumnField {
+	cp := *c
+	cp.NumberColumn = c.withAggregate(fn)
+	return &cp
+}
+
+func (c *Int16Column) Sum() *Int16Column {
+	return c.aggregated("SUM").(*Int16Column)
+}
+
+func (c *Int16Column) Min() *Int16Column {
+	return c.aggregated("MIN").(*Int16Column)
+}
+
+func (c *Int16Column) Max() *Int16Column {
+	return c.aggregated("MAX").(*Int16Column)
+}
+
+func (c *Int32Column) aggregated(fn string) ColumnField {
+	cp := *c
+	cp.NumberColumn = c.withAggregate(fn)
+	return &cp
+}
+
+func (c *Int32Column) Sum() *Int32Column {
+	return c.aggregated("SUM").(*Int32Column)
+}
+
+func (c *Int32Column) Min() *Int32Column {
+	return c.aggregated("MIN").(*Int32Column)
+}
+
+func (c *Int32Column) Max() *Int32Column {
+	return c.aggregated("MAX").(*Int32Column)
+}
+
+func (c *Int64Column) aggregated(fn string) ColumnField {
+	cp := *c
+	cp.NumberColumn = c.withAggregate(fn)
+	return &cp
+}
+
+func (c *Int64Column) Sum() *Int64Column {
+	return c.aggregated("SUM").(*Int64Column)
+}
+
+func (c *Int64Column) Min() *Int64Column {
+	return c.aggregated("MIN").(*Int64Column)
+}
+
+func (c *Int64Column) Max() *Int64Column {
+	return c.aggregated("MAX").(*Int64Column)
+}
+
+func (c *Float32Column) aggregated(fn string) ColumnField {
+	cp := *c
+	cp.NumberColumn = c.withAggregate(fn)
+	return &cp
+}
+
+func (c *Float32Column) Sum() *Float32Column {
+	return c.aggregated("SUM").(*Float32Column)
+}
+
+func (c *Float32Column) Min() *Float32Column {
+	return c.aggregated("MIN").(*Float32Column)
+}
+
+func (c *Float32Column) Max() *Float32Column {
+	return c.aggregated("MAX").(*Float32Column)
+}
+
+func (c *Float64Column) aggregated(fn string) ColumnField {
+	cp := *c
+	cp.NumberColumn = c.withAggregate(fn)
+	return &cp
+}
+
+func (c *Float64Column) Sum() *Float64Column {
+	return c.aggregated("SUM").(*Float64Column)
+}
+
+func (c *Float64Column) Min() *Float64Column {
+	return c.aggregated("MIN").(*Float64Column)
+}
+
+func (c *Float64Column) Max() *Float64Column {
+	return c.aggregated("MAX").(*Float64Column)
+}
+
+func (c *TextColumn) aggregated(fn string) ColumnField {
+	cp := *c
+	cp.alias = ""
+	cp.aggregate = fn
+	if cp.err == nil {
+		cp.err = nestedAggregateError(c, fn)
+	}
+	return &cp
+}
+
+func (c *TextColumn) isAggregate() bool {
+	return c.aggregate != ""
+}
+
+func (c *TextColumn) Min() *TextColumn {
+	return c.aggregated("MIN").(*TextColumn)
+}
+
+func (c *TextColumn) Max() *TextColumn {
+	return c.aggregated("MAX").(*TextColumn)
+}
+
+func (c *TimeColumn) aggregated(fn string) ColumnField {
+	cp := *c
+	cp.alias = ""
+	cp.aggregate = fn
+	if cp.err == nil {
+		cp.err = nestedAggregateError(c, fn)
+	}
+	return &cp
+}
+
+func (c *TimeColumn) isAggregate() bool {
+	return c.aggregate != ""
+}
+
+func (c *TimeColumn) Min() *TimeColumn {
+	return c.aggregated("MIN").(*TimeColumn)
+}
+
+func (c *TimeColumn) Max() *TimeColumn {
+	return c.aggregated("MAX").(*TimeColumn)
+}
@@ -0,0 +1,153 @@
+package model
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/tmarcus87/sqlike/dialect"
+	"testing"
+)
+
+// mysqlColumnExpr MySQLの条件句やソート順で使う式を返す
+func mysqlColumnExpr(c Column) string {
+	mysql, _ := dialect.Get(dialect.MySQL)
+	bindings := make([]interface{}, 0)
+	expr, _ := ColumnExpr(mysql, c, &bindings)
+	return expr
+}
+
+func TestAggregate(t *testing.T) {
+	tbl := NewTable("tbl")
+	i64 := NewInt64Column(tbl, "i64")
+	f32 := NewFloat32Column(tbl, "f32")
+	text := NewTextColumn(tbl, "text")
+	tm := NewTimeColumn(tbl, "tm")
+	b := NewBoolColumn(tbl, "b")
+
+	tests := []struct {
+		Name      string
+		Field     ColumnField
+		FieldExpr string
+		Expr      string
+	}{
+		{Name: "Sum", Field: i64.Sum(), FieldExpr: "SUM(`tbl`.`i64`)", Expr: "SUM(`tbl`.`i64`)"},
+		{Name: "Min", Field: f32.Min(), FieldExpr: "MIN(`tbl`.`f32`)", Expr: "MIN(`tbl`.`f32`)"},
+		{Name: "Max", Field: text.Max().As("max_text"), FieldExpr: "MAX(`tbl`.`text`) AS `max_text`", Expr: "MAX(`tbl`.`text`)"},
+		{Name: "MaxTime", Field: tm.Max(), FieldExpr: "MAX(`tbl`.`tm`)", Expr: "MAX(`tbl`.`tm`)"},
+		{Name: "Avg", Field: i64.Avg(), FieldExpr: "AVG(`tbl`.`i64`)", Expr: "AVG(`tbl`.`i64`)"},
+		{Name: "FuncSum", Field: Sum(i64), FieldExpr: "SUM(`tbl`.`i64`)", Expr: "SUM(`tbl`.`i64`)"},
+		{Name: "FuncAvg", Field: Avg(f32), FieldExpr: "AVG(`tbl`.`f32`)", Expr: "AVG(`tbl`.`f32`)"},
+		{Name: "FuncMaxBool", Field: Max(b), FieldExpr: "MAX(`tbl`.`b`)", Expr: "MAX(`tbl`.`b`)"},
+		{Name: "CountDistinct", Field: CountDistinct(text), FieldExpr: "COUNT(DISTINCT `tbl`.`text`)", Expr: "COUNT(DISTINCT `tbl`.`text`)"},
+		{Name: "CountAll", Field: CountAll().As("cnt"), FieldExpr: "COUNT(*) AS `cnt`", Expr: "COUNT(*)"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			asserts := assert.New(t)
+			asserts.Equal(test.FieldExpr, test.Field.FieldExpr())
+			asserts.Equal(test.Expr, mysqlColumnExpr(test.Field))
+		})
+	}
+
+	t.Run("KeepType", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		var (
+			stmt     string
+			bindings []interface{}
+		)
+		i64.Max().Gt(10).Apply(&stmt, &bindings)
+		asserts.Equal("MAX(`tbl`.`i64`) > ?", stmt)
+		asserts.Equal([]interface{}{int64(10)}, bindings)
+		asserts.Equal("MAX(`tbl`.`i64`)", mysqlColumnExpr(i64.Max().Desc().Column))
+
+		stmt, bindings = "", nil
+		Max(f32).Gt(10).And(Avg(i64).Lt(1.5)).Apply(&stmt, &bindings)
+		asserts.Equal("(MAX(`tbl`.`f32`) > ? AND AVG(`tbl`.`i64`) < ?)", stmt)
+		asserts.Equal([]interface{}{10, 1.5}, bindings)
+		asserts.Equal("MIN(`tbl`.`text`)", mysqlColumnExpr(Min(text).Asc().Column))
+
+		stmt, bindings = "", nil
+		Max(text).Gt("M").Apply(&stmt, &bindings)
+		asserts.Equal("MAX(`tbl`.`text`) > ?", stmt)
+		asserts.Equal([]interface{}{"M"}, bindings)
+		asserts.Equal("MAX(`tbl`.`text`)", Max(text).FieldExpr())
+	})
+
+	t.Run("Operand", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		asserts.Equal("SUM(DISTINCT `tbl`.`i64`) AS `total`", Sum(Distinct(i64)).As("total").FieldExpr())
+		asserts.Equal("SUM(DISTINCT `tbl`.`i64`)", mysqlColumnExpr(Sum(Distinct(i64))))
+		asserts.Equal("MAX(`tbl`.`b`) AS `max_b`", Max(NewBoolColumn(tbl, "b").As("b2")).As("max_b").FieldExpr())
+		asserts.Equal("COUNT(`tbl`.`text`)", Count(NewTextColumn(tbl, "text").As("t")).FieldExpr())
+	})
+
+	t.Run("Nested", func(t *testing.T) {
+		mysql, _ := dialect.Get(dialect.MySQL)
+
+		fields := []DialectField{
+			Sum(i64.Max()),
+			Avg(i64.Sum()),
+			Max(CountAll()),
+			Sum(Distinct(text.Max())),
+			i64.Sum().Max(),
+			text.Min().Max(),
+			Count(i64.Sum()),
+			GroupConcat(tm.Max()),
+		}
+		for _, field := range fields {
+			bindings := make([]interface{}, 0)
+			_, err := field.SQLikeFieldExpr(mysql, &bindings)
+			assert.True(t, errors.Is(err, ErrorNestedAggregate), field.ColumnName())
+		}
+	})
+
+	t.Run("OriginalColumn", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		_ = i64.Sum()
+		asserts.Equal("`tbl`.`i64`", i64.FieldExpr())
+		asserts.Equal("`tbl`.`i64`", mysqlColumnExpr(i64))
+	})
+}
+
+func TestGroupConcat(t *testing.T) {
+	tbl := NewTable("tbl")
+	id := NewInt64Column(tbl, "id")
+	name := NewTextColumn(tbl, "name")
+
+	mysql, _ := dialect.Get(dialect.MySQL)
+	postgres, _ := dialect.Get(dialect.Postgres)
+	sqlite3, _ := dialect.Get(dialect.Sqlite3)
+
+	bindings := make([]interface{}, 0)
+
+	t.Run("MySQL", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		expr, err := GroupConcat(name).Separator("; ").OrderBy(id.Desc()).As("names").(DialectField).SQLikeFieldExpr(mysql, &bindings)
+		asserts.Nil(err)
+		asserts.Equal("GROUP_CONCAT(`tbl`.`name` ORDER BY `tbl`.`id` DESC SEPARATOR '; ') AS `names`", expr)
+		asserts.Equal(expr, GroupConcat(name).Separator("; ").OrderBy(id.Desc()).As("names").FieldExpr())
+	})
+
+	t.Run("Postgres", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		expr, err := GroupConcat(id).Separator("'").OrderBy(id.Asc()).SQLikeFieldExpr(postgres, &bindings)
+		asserts.Nil(err)
+		asserts.Equal(`STRING_AGG(CAST("tbl"."id" AS TEXT), '''' ORDER BY "tbl"."id" ASC)`, expr)
+	})
+
+	t.Run("Sqlite3", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		expr, err := GroupConcat(name).SQLikeFieldExpr(sqlite3, &bindings)
+		asserts.Nil(err)
+		asserts.Equal(`GROUP_CONCAT("tbl"."name", ',')`, expr)
+
+		_, err = GroupConcat(name).OrderBy(id.Asc()).SQLikeFieldExpr(sqlite3, &bindings)
+		asserts.NotNil(err)
+	})
+}
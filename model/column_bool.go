@@ -1,6 +1,9 @@
 package model
 
-import "database/sql"
+import (
+	"database/sql"
+	"github.com/tmarcus87/sqlike/dialect"
+)
 
 func NewBoolColumn(table Table, name string) *BoolColumn {
 	return &BoolColumn{table: table, name: name}
@@ -34,7 +37,11 @@ func (c *BoolColumn) As(alias string) ColumnField {
 }
 
 func (c *BoolColumn) FieldExpr() string {
-	return fieldExpr(c, c.alias, "")
+	return compatFieldExpr(c)
+}
+
+func (c *BoolColumn) SQLikeFieldExpr(d dialect.Dialect, _ *[]interface{}) (string, error) {
+	return fieldExpr(d, c, c.alias, ""), nil
 }
 
 func (c *BoolColumn) ColumnValue() interface{} {
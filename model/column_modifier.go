@@ -1,16 +1,37 @@
 package model
 
-import "fmt"
+import (
+	"fmt"
+	"github.com/tmarcus87/sqlike/dialect"
+	"strings"
+)
 
-func Count(field ColumnField) ColumnField {
+func Count(field ColumnField) *CountColumnModifier {
 	return &CountColumnModifier{
 		column: field,
+		err:    nestedAggregateError(field, "COUNT"),
 	}
 }
 
+// CountDistinct returns COUNT(DISTINCT field)
+func CountDistinct(field ColumnField) *CountColumnModifier {
+	return &CountColumnModifier{
+		column:   field,
+		distinct: true,
+		err:      nestedAggregateError(field, "COUNT"),
+	}
+}
+
+// CountAll returns COUNT(*)
+func CountAll() *CountColumnModifier {
+	return Count(NewAllColumnField())
+}
+
 type CountColumnModifier struct {
-	column ColumnField
-	alias  string
+	column   ColumnField
+	alias    string
+	distinct bool
+	err      error
 }
 
 func (c *CountColumnModifier) Table() Table {
@@ -31,11 +52,71 @@ func (c *CountColumnModifier) As(alias string) ColumnField {
 }
 
 func (c *CountColumnModifier) FieldExpr() string {
-	expr := fmt.Sprintf("COUNT(%s)", c.column.FieldExpr())
-	if c.alias != "" {
-		expr += fmt.Sprintf(" AS `%s`", c.alias)
+	return compatFieldExpr(c)
+}
+
+func (c *CountColumnModifier) SQLikeFieldExpr(d dialect.Dialect, bindings *[]interface{}) (string, error) {
+	expr, err := c.SQLikeExpr(d, bindings)
+	if err != nil {
+		return "", err
+	}
+	return aliasExpr(d, expr, c.alias), nil
+}
+
+func (c *CountColumnModifier) SQLikeExpr(d dialect.Dialect, bindings *[]interface{}) (string, error) {
+	if c.err != nil {
+		return "", c.err
+	}
+	expr, err := ColumnExpr(d, c.column, bindings)
+	if err != nil {
+		return "", err
+	}
+	if c.distinct {
+		return fmt.Sprintf("COUNT(DISTINCT %s)", expr), nil
+	}
+	return fmt.Sprintf("COUNT(%s)", expr), nil
+}
+
+func (c *CountColumnModifier) isAggregate() bool {
+	return true
+}
+
+func (c *CountColumnModifier) Eq(v int64) Condition {
+	return &SingleValueCondition{Column: c, Operator: "=", Value: v}
+}
+
+func (c *CountColumnModifier) NotEq(v int64) Condition {
+	return &SingleValueCondition{Column: c, Operator: "!=", Value: v}
+}
+
+func (c *CountColumnModifier) Gt(v int64) Condition {
+	return &SingleValueCondition{Column: c, Operator: ">", Value: v}
+}
+
+func (c *CountColumnModifier) GtOrEq(v int64) Condition {
+	return &SingleValueCondition{Column: c, Operator: ">=", Value: v}
+}
+
+func (c *CountColumnModifier) Lt(v int64) Condition {
+	return &SingleValueCondition{Column: c, Operator: "<", Value: v}
+}
+
+func (c *CountColumnModifier) LtOrEq(v int64) Condition {
+	return &SingleValueCondition{Column: c, Operator: "<=", Value: v}
+}
+
+func (c *CountColumnModifier) Asc() *SortOrder {
+	return &SortOrder{
+		Column: c,
+		Order:  OrderAsc,
+	}
+}
+
+func (c *CountColumnModifier) Desc() *SortOrder {
+	return &SortOrder{
+		Column: c,
+		Order:  OrderDesc,
 	}
-	return expr
 }
 
 func Distinct(field ColumnField) ColumnField {
@@ -66,10 +147,107 @@ func (c *DistinctColumnModifier) As(alias string) ColumnField {
 	return c
 }
 
+func (c *DistinctColumnModifier) isAggregate() bool {
+	a, ok := c.column.(aggregateField)
+	return ok && a.isAggregate()
+}
+
 func (c *DistinctColumnModifier) FieldExpr() string {
-	expr := fmt.Sprintf("DISTINCT %s", c.column.FieldExpr())
+	return compatFieldExpr(c)
+}
+
+func (c *DistinctColumnModifier) SQLikeFieldExpr(d dialect.Dialect, bindings *[]interface{}) (string, error) {
+	expr, err := c.SQLikeExpr(d, bindings)
+	if err != nil {
+		return "", err
+	}
+	return aliasExpr(d, expr, c.alias), nil
+}
+
+func (c *DistinctColumnModifier) SQLikeExpr(d dialect.Dialect, bindings *[]interface{}) (string, error) {
+	expr, err := ColumnExpr(d, c.column, bindings)
+	if err != nil {
+		return "", err
+	}
+	return "DISTINCT " + expr, nil
+}
+
+// GroupConcat returns the concatenation of the values in the group,
+// which is GROUP_CONCAT in MySQL and STRING_AGG in PostgreSQL.
+// The default separator is ','.
+func GroupConcat(field ColumnField) *GroupConcatColumnModifier {
+	return &GroupConcatColumnModifier{
+		column:    field,
+		separator: ",",
+		err:       nestedAggregateError(field, "GROUP_CONCAT"),
+	}
+}
+
+type GroupConcatColumnModifier struct {
+	column    ColumnField
+	alias     string
+	separator string
+	orders    []*SortOrder
+	err       error
+}
+
+func (c *GroupConcatColumnModifier) Table() Table {
+	return c.column.Table()
+}
+
+func (c *GroupConcatColumnModifier) ColumnName() string {
+	return c.column.ColumnName()
+}
+
+func (c *GroupConcatColumnModifier) AliasOrName() string {
 	if c.alias != "" {
-		expr += fmt.Sprintf(" AS `%s`", c.alias)
+		return c.alias
+	}
+	return c.column.AliasOrName()
+}
+
+func (c *GroupConcatColumnModifier) As(alias string) ColumnField {
+	c.alias = alias
+	return c
+}
+
+// Separator sets the separator of the values
+func (c *GroupConcatColumnModifier) Separator(separator string) *GroupConcatColumnModifier {
+	c.separator = separator
+	return c
+}
+
+// OrderBy sets the order of the values
+func (c *GroupConcatColumnModifier) OrderBy(orders ...*SortOrder) *GroupConcatColumnModifier {
+	c.orders = orders
+	return c
+}
+
+// FieldExpr returns the expression of MySQL, use SQLikeFieldExpr for other dialects
+func (c *GroupConcatColumnModifier) FieldExpr() string {
+	return compatFieldExpr(c)
+}
+
+func (c *GroupConcatColumnModifier) isAggregate() bool {
+	return true
+}
+
+func (c *GroupConcatColumnModifier) SQLikeFieldExpr(d dialect.Dialect, bindings *[]interface{}) (string, error) {
+	if c.err != nil {
+		return "", c.err
+	}
+	column, err := ColumnExpr(d, c.column, bindings)
+	if err != nil {
+		return "", err
+	}
+	orders, err := orderExprs(d, c.orders, bindings)
+	if err != nil {
+		return "", err
+	}
+
+	expr, err := d.GroupConcat(column, c.separator, strings.Join(orders, ", "))
+	if err != nil {
+		return "", err
 	}
-	return expr
+	return aliasExpr(d, expr, c.alias), nil
 }
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/tmarcus87/sqlike/dialect"
+	"testing"
+)
+
+func TestCountColumnModifier_Condition(t *testing.T) {
+	tbl := NewTable("tbl")
+	col := NewInt64Column(tbl, "col")
+
+	mysql, _ := dialect.Get(dialect.MySQL)
+
+	tests := []struct {
+		Name   string
+		Cond   Condition
+		Expect string
+	}{
+		{Name: "Eq", Cond: Count(col).Eq(1), Expect: "COUNT(`tbl`.`col`) = ?"},
+		{Name: "NotEq", Cond: Count(col).NotEq(1), Expect: "COUNT(`tbl`.`col`) != ?"},
+		{Name: "Gt", Cond: Count(col).Gt(1), Expect: "COUNT(`tbl`.`col`) > ?"},
+		{Name: "GtOrEq", Cond: Count(col).GtOrEq(1), Expect: "COUNT(`tbl`.`col`) >= ?"},
+		{Name: "Lt", Cond: Count(col).Lt(1), Expect: "COUNT(`tbl`.`col`) < ?"},
+		{Name: "LtOrEq", Cond: Count(col).LtOrEq(1), Expect: "COUNT(`tbl`.`col`) <= ?"},
+		{Name: "All", Cond: Count(NewAllColumnField()).Gt(1), Expect: "COUNT(*) > ?"},
+		{Name: "WithAlias", Cond: Count(col).As("cnt").(*CountColumnModifier).Gt(1), Expect: "COUNT(`tbl`.`col`) > ?"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var (
+				stmt     string
+				bindings []interface{}
+			)
+
+			asserts := assert.New(t)
+			asserts.Nil(ApplyCondition(mysql, test.Cond, &stmt, &bindings))
+			asserts.Equal(test.Expect, stmt)
+			asserts.Equal([]interface{}{int64(1)}, bindings)
+		})
+	}
+}
@@ -2,7 +2,7 @@ package model
 
 import (
 	"database/sql"
-	"fmt"
+	"github.com/tmarcus87/sqlike/dialect"
 )
 
 type NumericField interface {
@@ -21,7 +21,18 @@ type NumberColumn struct {
 	table Table
 	name  string
 	alias string
-	expr  string
+
+	// calcs カラムに重ねる計算
+	calcs []calculation
+
+	// aggregate 集約関数
+	aggregate string
+
+	// operand 数値のカラム以外を集約関数で囲む場合の式
+	operand ColumnField
+
+	// err 集約関数の入れ子など、出力時に返すエラー
+	err error
 }
 
 func (c *NumberColumn) Table() Table {
@@ -46,47 +57,108 @@ func (c *NumberColumn) As(alias string) ColumnField {
 }
 
 func (c *NumberColumn) FieldExpr() string {
-	return fieldExpr(c, c.alias, c.expr)
+	return compatFieldExpr(c)
 }
 
-func (c *NumberColumn) PlusInt(v int) NumericField {
-	c.expr = calcExpr(c, c.expr, fmt.Sprintf("$$ + %d", v))
+func (c *NumberColumn) SQLikeFieldExpr(d dialect.Dialect, bindings *[]interface{}) (string, error) {
+	if c.err != nil {
+		return "", c.err
+	}
+	if c.operand != nil {
+		expr, err := c.operandExpr(d, bindings)
+		if err != nil {
+			return "", err
+		}
+		return aliasExpr(d, expr, c.alias), nil
+	}
+	expr, err := c.calcExpr(d, quotedColumn(d, c), bindings)
+	if err != nil {
+		return "", err
+	}
+	return aliasExpr(d, expr, c.alias), nil
+}
+
+func (c *NumberColumn) SQLikeExpr(d dialect.Dialect, bindings *[]interface{}) (string, error) {
+	if c.err != nil {
+		return "", c.err
+	}
+	if c.operand != nil {
+		return c.operandExpr(d, bindings)
+	}
+	if c.aggregate == "" {
+		return quotedColumn(d, c), nil
+	}
+	return c.calcExpr(d, quotedColumn(d, c), bindings)
+}
+
+// operandExpr カラムの代わりに式を集約関数で囲みます
+func (c *NumberColumn) operandExpr(d dialect.Dialect, bindings *[]interface{}) (string, error) {
+	operand, err := ColumnExpr(d, c.operand, bindings)
+	if err != nil {
+		return "", err
+	}
+	return c.calcExpr(d, operand, bindings)
+}
+
+// calculation 計算の演算子と値。値はバインド変数として出力します
+type calculation struct {
+	operator string
+	value    interface{}
+}
+
+// calcExpr カラムの式に計算を重ね、集約関数が指定されている場合は集約関数で囲みます
+func (c *NumberColumn) calcExpr(d dialect.Dialect, column string, bindings *[]interface{}) (string, error) {
+	expr := column
+	for i, calc := range c.calcs {
+		value, err := operandExpr(d, calc.value, bindings)
+		if err != nil {
+			return "", err
+		}
+		if i > 0 {
+			expr = "(" + expr + ")"
+		}
+		expr = expr + " " + calc.operator + " " + value
+	}
+	return aggregateExpr(expr, c.aggregate), nil
+}
+
+// calc 計算を追加します。集約関数で囲んだコピーと計算を共有しないように、スライスは都度複製します
+func (c *NumberColumn) calc(operator string, v interface{}) NumericField {
+	calcs := make([]calculation, 0, len(c.calcs)+1)
+	c.calcs = append(append(calcs, c.calcs...), calculation{operator: operator, value: v})
 	return c
 }
 
+func (c *NumberColumn) PlusInt(v int) NumericField {
+	return c.calc("+", v)
+}
+
 func (c *NumberColumn) PlusFloat(v float64) NumericField {
-	c.expr = calcExpr(c, c.expr, fmt.Sprintf("$$ + %g", v))
-	return c
+	return c.calc("+", v)
 }
 
 func (c *NumberColumn) MinusInt(v int) NumericField {
-	c.expr = calcExpr(c, c.expr, fmt.Sprintf("$$ - %d", v))
-	return c
+	return c.calc("-", v)
 }
 
 func (c *NumberColumn) MinusFloat(v float64) NumericField {
-	c.expr = calcExpr(c, c.expr, fmt.Sprintf("$$ - %g", v))
-	return c
+	return c.calc("-", v)
 }
 
 func (c *NumberColumn) MultipleInt(v int) NumericField {
-	c.expr = calcExpr(c, c.expr, fmt.Sprintf("$$ * %d", v))
-	return c
+	return c.calc("*", v)
 }
 
 func (c *NumberColumn) MultipleFloat(v float64) NumericField {
-	c.expr = calcExpr(c, c.expr, fmt.Sprintf("$$ * %g", v))
-	return c
+	return c.calc("*", v)
 }
 
 func (c *NumberColumn) DivideInt(v int) NumericField {
-	c.expr = calcExpr(c, c.expr, fmt.Sprintf("$$ / %d", v))
-	return c
+	return c.calc("/", v)
 }
 
 func (c *NumberColumn) DivideFloat(v float64) NumericField {
-	c.expr = calcExpr(c, c.expr, fmt.Sprintf("$$ / %g", v))
-	return c
+	return c.calc("/", v)
 }
 
 func (c *NumberColumn) Asc() *SortOrder {
@@ -180,6 +252,14 @@ func (c *Int8Column) NotIn(vs ...int8) Condition {
 	}
 }
 
+func (c *Int8Column) InSubQuery(sq SubQuery) Condition {
+	return &SubQueryCondition{Column: c, Operator: "IN", SubQuery: sq}
+}
+
+func (c *Int8Column) NotInSubQuery(sq SubQuery) Condition {
+	return &SubQueryCondition{Column: c, Operator: "NOT IN", SubQuery: sq}
+}
+
 func Int8SliceToInterfaceSlice(in []int8) []interface{} {
 	out := make([]interface{}, 0)
 	for _, v := range in {
@@ -265,6 +345,14 @@ func (c *Int16Column) NotIn(vs ...int16) Condition {
 	}
 }
 
+func (c *Int16Column) InSubQuery(sq SubQuery) Condition {
+	return &SubQueryCondition{Column: c, Operator: "IN", SubQuery: sq}
+}
+
+func (c *Int16Column) NotInSubQuery(sq SubQuery) Condition {
+	return &SubQueryCondition{Column: c, Operator: "NOT IN", SubQuery: sq}
+}
+
 func Int16SliceToInterfaceSlice(in []int16) []interface{} {
 	out := make([]interface{}, 0)
 	for _, v := range in {
@@ -350,6 +438,14 @@ func (c *Int32Column) NotIn(vs ...int32) Condition {
 	}
 }
 
+func (c *Int32Column) InSubQuery(sq SubQuery) Condition {
+	return &SubQueryCondition{Column: c, Operator: "IN", SubQuery: sq}
+}
+
+func (c *Int32Column) NotInSubQuery(sq SubQuery) Condition {
+	return &SubQueryCondition{Column: c, Operator: "NOT IN", SubQuery: sq}
+}
+
 func Int32SliceToInterfaceSlice(in []int32) []interface{} {
 	out := make([]interface{}, 0)
 	for _, v := range in {
@@ -435,6 +531,14 @@ func (c *Int64Column) NotIn(vs ...int64) Condition {
 	}
 }
 
+func (c *Int64Column) InSubQuery(sq SubQuery) Condition {
+	return &SubQueryCondition{Column: c, Operator: "IN", SubQuery: sq}
+}
+
+func (c *Int64Column) NotInSubQuery(sq SubQuery) Condition {
+	return &SubQueryCondition{Column: c, Operator: "NOT IN", SubQuery: sq}
+}
+
 func Int64SliceToInterfaceSlice(in []int64) []interface{} {
 	out := make([]interface{}, 0)
 	for _, v := range in {
@@ -520,6 +624,14 @@ func (c *Float32Column) NotIn(vs ...float32) Condition {
 	}
 }
 
+func (c *Float32Column) InSubQuery(sq SubQuery) Condition {
+	return &SubQueryCondition{Column: c, Operator: "IN", SubQuery: sq}
+}
+
+func (c *Float32Column) NotInSubQuery(sq SubQuery) Condition {
+	return &SubQueryCondition{Column: c, Operator: "NOT IN", SubQuery: sq}
+}
+
 func Float32SliceToInterfaceSlice(in []float32) []interface{} {
 	out := make([]interface{}, 0)
 	for _, v := range in {
@@ -605,6 +717,14 @@ func (c *Float64Column) NotIn(vs ...float64) Condition {
 	}
 }
 
+func (c *Float64Column) InSubQuery(sq SubQuery) Condition {
+	return &SubQueryCondition{Column: c, Operator: "IN", SubQuery: sq}
+}
+
+func (c *Float64Column) NotInSubQuery(sq SubQuery) Condition {
+	return &SubQueryCondition{Column: c, Operator: "NOT IN", SubQuery: sq}
+}
+
 func Float64SliceToInterfaceSlice(in []float64) []interface{} {
 	out := make([]interface{}, 0)
 	for _, v := range in {
@@ -2,6 +2,7 @@ package model
 
 import (
 	"github.com/stretchr/testify/assert"
+	"github.com/tmarcus87/sqlike/dialect"
 	"testing"
 )
 
@@ -10,8 +11,9 @@ func TestNumericColumn_SQLikeFieldExpr(t *testing.T) {
 	tblAlias := NewTable("tbl").As("tbl_alias")
 
 	tests := []struct {
-		Expect string
-		Column ColumnField
+		Expect   string
+		Bindings []interface{}
+		Column   ColumnField
 	}{
 		{
 			Expect: "`tbl`.`col`",
@@ -30,54 +32,90 @@ func TestNumericColumn_SQLikeFieldExpr(t *testing.T) {
 			Column: (&NumberColumn{table: tblAlias, name: "col"}).As("col_alias"),
 		},
 		{
-			Expect: "`tbl`.`col` + 123",
-			Column: (&NumberColumn{table: tbl, name: "col"}).PlusInt(123),
+			Expect:   "`tbl`.`col` + ?",
+			Bindings: []interface{}{123},
+			Column:   (&NumberColumn{table: tbl, name: "col"}).PlusInt(123),
 		},
 		{
-			Expect: "`tbl`.`col` + 123.4",
-			Column: (&NumberColumn{table: tbl, name: "col"}).PlusFloat(123.4),
+			Expect:   "`tbl`.`col` + ?",
+			Bindings: []interface{}{123.4},
+			Column:   (&NumberColumn{table: tbl, name: "col"}).PlusFloat(123.4),
 		},
 		{
-			Expect: "`tbl`.`col` - 123",
-			Column: (&NumberColumn{table: tbl, name: "col"}).MinusInt(123),
+			Expect:   "`tbl`.`col` - ?",
+			Bindings: []interface{}{123},
+			Column:   (&NumberColumn{table: tbl, name: "col"}).MinusInt(123),
 		},
 		{
-			Expect: "`tbl`.`col` - 123.4",
-			Column: (&NumberColumn{table: tbl, name: "col"}).MinusFloat(123.4),
+			Expect:   "`tbl`.`col` - ?",
+			Bindings: []interface{}{123.4},
+			Column:   (&NumberColumn{table: tbl, name: "col"}).MinusFloat(123.4),
 		},
 		{
-			Expect: "`tbl`.`col` * 123",
-			Column: (&NumberColumn{table: tbl, name: "col"}).MultipleInt(123),
+			Expect:   "`tbl`.`col` * ?",
+			Bindings: []interface{}{123},
+			Column:   (&NumberColumn{table: tbl, name: "col"}).MultipleInt(123),
 		},
 		{
-			Expect: "`tbl`.`col` * 123.4",
-			Column: (&NumberColumn{table: tbl, name: "col"}).MultipleFloat(123.4),
+			Expect:   "`tbl`.`col` * ?",
+			Bindings: []interface{}{123.4},
+			Column:   (&NumberColumn{table: tbl, name: "col"}).MultipleFloat(123.4),
 		},
 		{
-			Expect: "`tbl`.`col` / 123",
-			Column: (&NumberColumn{table: tbl, name: "col"}).DivideInt(123),
+			Expect:   "`tbl`.`col` / ?",
+			Bindings: []interface{}{123},
+			Column:   (&NumberColumn{table: tbl, name: "col"}).DivideInt(123),
 		},
 		{
-			Expect: "`tbl`.`col` / 123.4",
-			Column: (&NumberColumn{table: tbl, name: "col"}).DivideFloat(123.4),
+			Expect:   "`tbl`.`col` / ?",
+			Bindings: []interface{}{123.4},
+			Column:   (&NumberColumn{table: tbl, name: "col"}).DivideFloat(123.4),
 		},
 		{
-			Expect: "(`tbl`.`col` + 123) * 456",
-			Column: (&NumberColumn{table: tbl, name: "col"}).PlusInt(123).MultipleInt(456),
+			Expect:   "(`tbl`.`col` + ?) * ?",
+			Bindings: []interface{}{123, 456},
+			Column:   (&NumberColumn{table: tbl, name: "col"}).PlusInt(123).MultipleInt(456),
 		},
 		{
-			Expect: "`tbl`.`col` + 123 AS `col_plus`",
-			Column: (&NumberColumn{table: tbl, name: "col"}).PlusInt(123).As("col_plus"),
+			Expect:   "`tbl`.`col` + ? AS `col_plus`",
+			Bindings: []interface{}{123},
+			Column:   (&NumberColumn{table: tbl, name: "col"}).PlusInt(123).As("col_plus"),
+		},
+		{
+			Expect:   "SUM(`tbl`.`col` + ?)",
+			Bindings: []interface{}{1},
+			Column:   NewInt64Column(tbl, "col").Sum().PlusInt(1),
 		},
 	}
 
+	mysql, _ := dialect.Get(dialect.MySQL)
+
 	for _, test := range tests {
 		t.Run(test.Expect, func(t *testing.T) {
 			asserts := assert.New(t)
 
-			asserts.Equal(test.Expect, test.Column.FieldExpr())
+			bindings := make([]interface{}, 0)
+			expr, err := test.Column.(DialectField).SQLikeFieldExpr(mysql, &bindings)
+			asserts.Nil(err)
+			asserts.Equal(test.Expect, expr)
+			if test.Bindings == nil {
+				asserts.Empty(bindings)
+			} else {
+				asserts.Equal(test.Bindings, bindings)
+			}
 		})
 	}
+
+	t.Run("Postgres", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		postgres, _ := dialect.Get(dialect.Postgres)
+		bindings := []interface{}{"a"}
+		expr, err := (&NumberColumn{table: tbl, name: "col"}).PlusInt(1).DivideFloat(2.5).(DialectField).SQLikeFieldExpr(postgres, &bindings)
+		asserts.Nil(err)
+		asserts.Equal(`("tbl"."col" + $2) / $3`, expr)
+		asserts.Equal([]interface{}{"a", 1, 2.5}, bindings)
+	})
 }
 
 func TestInt8Column_SetAndColumnValue(t *testing.T) {
@@ -1,6 +1,9 @@
 package model
 
-import "database/sql"
+import (
+	"database/sql"
+	"github.com/tmarcus87/sqlike/dialect"
+)
 
 func NewTextColumn(table Table, name string) *TextColumn {
 	return &TextColumn{table: table, name: name}
@@ -16,6 +19,12 @@ type TextColumn struct {
 	alias string
 	expr  string
 	value sql.NullString
+
+	// aggregate 集約関数
+	aggregate string
+
+	// err 集約関数の入れ子など、出力時に返すエラー
+	err error
 }
 
 func (c *TextColumn) Table() Table {
@@ -39,7 +48,21 @@ func (c *TextColumn) As(alias string) ColumnField {
 }
 
 func (c *TextColumn) FieldExpr() string {
-	return fieldExpr(c, c.alias, c.expr)
+	return compatFieldExpr(c)
+}
+
+func (c *TextColumn) SQLikeFieldExpr(d dialect.Dialect, _ *[]interface{}) (string, error) {
+	if c.err != nil {
+		return "", c.err
+	}
+	return fieldExpr(d, c, c.alias, aggregateExpr(c.expr, c.aggregate)), nil
+}
+
+func (c *TextColumn) SQLikeExpr(d dialect.Dialect, _ *[]interface{}) (string, error) {
+	if c.err != nil {
+		return "", c.err
+	}
+	return conditionExpr(d, c, c.expr, c.aggregate), nil
 }
 
 func (c *TextColumn) NullValue() ColumnValue {
@@ -114,6 +137,14 @@ func (c *TextColumn) NotIn(vs ...string) Condition {
 	}
 }
 
+func (c *TextColumn) InSubQuery(sq SubQuery) Condition {
+	return &SubQueryCondition{Column: c, Operator: "IN", SubQuery: sq}
+}
+
+func (c *TextColumn) NotInSubQuery(sq SubQuery) Condition {
+	return &SubQueryCondition{Column: c, Operator: "NOT IN", SubQuery: sq}
+}
+
 func (c *TextColumn) Asc() *SortOrder {
 	return &SortOrder{
 		Column: c,
@@ -2,6 +2,7 @@ package model
 
 import (
 	"database/sql"
+	"github.com/tmarcus87/sqlike/dialect"
 	"time"
 )
 
@@ -19,6 +20,12 @@ type TimeColumn struct {
 	alias string
 	expr  string
 	value sql.NullTime
+
+	// aggregate 集約関数
+	aggregate string
+
+	// err 集約関数の入れ子など、出力時に返すエラー
+	err error
 }
 
 func (c *TimeColumn) Table() Table {
@@ -42,7 +49,21 @@ func (c *TimeColumn) As(alias string) ColumnField {
 }
 
 func (c *TimeColumn) FieldExpr() string {
-	return fieldExpr(c, c.alias, c.expr)
+	return compatFieldExpr(c)
+}
+
+func (c *TimeColumn) SQLikeFieldExpr(d dialect.Dialect, _ *[]interface{}) (string, error) {
+	if c.err != nil {
+		return "", c.err
+	}
+	return fieldExpr(d, c, c.alias, aggregateExpr(c.expr, c.aggregate)), nil
+}
+
+func (c *TimeColumn) SQLikeExpr(d dialect.Dialect, _ *[]interface{}) (string, error) {
+	if c.err != nil {
+		return "", c.err
+	}
+	return conditionExpr(d, c, c.expr, c.aggregate), nil
 }
 
 func (c *TimeColumn) NullValue() ColumnValue {
@@ -113,6 +134,14 @@ func (c *TimeColumn) NotIn(vs ...time.Time) Condition {
 	}
 }
 
+func (c *TimeColumn) InSubQuery(sq SubQuery) Condition {
+	return &SubQueryCondition{Column: c, Operator: "IN", SubQuery: sq}
+}
+
+func (c *TimeColumn) NotInSubQuery(sq SubQuery) Condition {
+	return &SubQueryCondition{Column: c, Operator: "NOT IN", SubQuery: sq}
+}
+
 func (c *TimeColumn) Asc() *SortOrder {
 	return &SortOrder{
 		Column: c,
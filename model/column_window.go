@@ -0,0 +1,246 @@
+package model
+
+import (
+	"fmt"
+	"github.com/tmarcus87/sqlike/dialect"
+	"strings"
+)
+
+const (
+	FrameRows  = "ROWS"
+	FrameRange = "RANGE"
+)
+
+// WindowFunction is the function which is used with the OVER clause
+type WindowFunction struct {
+	fn     string
+	column ColumnField
+	args   []string
+}
+
+// RowNumber returns ROW_NUMBER()
+func RowNumber() *WindowFunction {
+	return &WindowFunction{fn: "ROW_NUMBER"}
+}
+
+// Rank returns RANK()
+func Rank() *WindowFunction {
+	return &WindowFunction{fn: "RANK"}
+}
+
+// DenseRank returns DENSE_RANK()
+func DenseRank() *WindowFunction {
+	return &WindowFunction{fn: "DENSE_RANK"}
+}
+
+// NTile returns NTILE(n)
+func NTile(n int) *WindowFunction {
+	return &WindowFunction{fn: "NTILE", args: []string{fmt.Sprint(n)}}
+}
+
+// Lag returns LAG(field, offset), which is the value of the preceding row
+func Lag(field ColumnField, offset int) *WindowFunction {
+	return &WindowFunction{fn: "LAG", column: field, args: []string{fmt.Sprint(offset)}}
+}
+
+// Lead returns LEAD(field, offset), which is the value of the following row
+func Lead(field ColumnField, offset int) *WindowFunction {
+	return &WindowFunction{fn: "LEAD", column: field, args: []string{fmt.Sprint(offset)}}
+}
+
+// FirstValue returns FIRST_VALUE(field)
+func FirstValue(field ColumnField) *WindowFunction {
+	return &WindowFunction{fn: "FIRST_VALUE", column: field}
+}
+
+// LastValue returns LAST_VALUE(field)
+func LastValue(field ColumnField) *WindowFunction {
+	return &WindowFunction{fn: "LAST_VALUE", column: field}
+}
+
+// Aggregate uses the aggregate function such as Sum(col) or Count(col) as the window function
+func Aggregate(field ColumnField) *WindowFunction {
+	return &WindowFunction{column: field}
+}
+
+func (f *WindowFunction) expr(d dialect.Dialect, bindings *[]interface{}) (string, error) {
+	// 集約関数はそのまま窓関数として使う
+	if f.fn == "" {
+		return ColumnExpr(d, f.column, bindings)
+	}
+	args := make([]string, 0)
+	if f.column != nil {
+		arg, err := ColumnExpr(d, f.column, bindings)
+		if err != nil {
+			return "", err
+		}
+		args = append(args, arg)
+	}
+	args = append(args, f.args...)
+	return fmt.Sprintf("%s(%s)", f.fn, strings.Join(args, ", ")), nil
+}
+
+// Over returns the window expression with the PARTITION BY, ORDER BY and frame clauses
+func (f *WindowFunction) Over(clauses ...WindowClause) *WindowColumnModifier {
+	return &WindowColumnModifier{
+		function: f,
+		clauses:  clauses,
+	}
+}
+
+// WindowClause is the clause in the OVER clause
+type WindowClause interface {
+	windowClause(d dialect.Dialect, bindings *[]interface{}) (string, error)
+}
+
+type partitionByClause struct {
+	columns []ColumnField
+}
+
+// PartitionBy returns PARTITION BY clause
+func PartitionBy(columns ...ColumnField) WindowClause {
+	return &partitionByClause{columns: columns}
+}
+
+func (c *partitionByClause) windowClause(d dialect.Dialect, bindings *[]interface{}) (string, error) {
+	cols := make([]string, 0)
+	for _, column := range c.columns {
+		col, err := ColumnExpr(d, column, bindings)
+		if err != nil {
+			return "", err
+		}
+		cols = append(cols, col)
+	}
+	return "PARTITION BY " + strings.Join(cols, ", "), nil
+}
+
+type orderByClause struct {
+	orders []*SortOrder
+}
+
+// OrderBy returns ORDER BY clause
+func OrderBy(orders ...*SortOrder) WindowClause {
+	return &orderByClause{orders: orders}
+}
+
+func (c *orderByClause) windowClause(d dialect.Dialect, bindings *[]interface{}) (string, error) {
+	orders, err := orderExprs(d, c.orders, bindings)
+	if err != nil {
+		return "", err
+	}
+	return "ORDER BY " + strings.Join(orders, ", "), nil
+}
+
+// FrameBound is the start or the end of the frame
+type FrameBound string
+
+const (
+	UnboundedPreceding FrameBound = "UNBOUNDED PRECEDING"
+	CurrentRow         FrameBound = "CURRENT ROW"
+	UnboundedFollowing FrameBound = "UNBOUNDED FOLLOWING"
+)
+
+// Preceding returns "n PRECEDING"
+func Preceding(n int) FrameBound {
+	return FrameBound(fmt.Sprintf("%d PRECEDING", n))
+}
+
+// Following returns "n FOLLOWING"
+func Following(n int) FrameBound {
+	return FrameBound(fmt.Sprintf("%d FOLLOWING", n))
+}
+
+type frameClause struct {
+	unit  string
+	start FrameBound
+	end   FrameBound
+}
+
+// RowsBetween returns ROWS BETWEEN start AND end
+func RowsBetween(start, end FrameBound) WindowClause {
+	return &frameClause{unit: FrameRows, start: start, end: end}
+}
+
+// RangeBetween returns RANGE BETWEEN start AND end
+func RangeBetween(start, end FrameBound) WindowClause {
+	return &frameClause{unit: FrameRange, start: start, end: end}
+}
+
+func (c *frameClause) windowClause(dialect.Dialect, *[]interface{}) (string, error) {
+	return fmt.Sprintf("%s BETWEEN %s AND %s", c.unit, c.start, c.end), nil
+}
+
+// WindowColumnModifier is the window function with the OVER clause
+type WindowColumnModifier struct {
+	function *WindowFunction
+	clauses  []WindowClause
+	alias    string
+}
+
+func (c *WindowColumnModifier) Table() Table {
+	if c.function.column == nil {
+		return nil
+	}
+	return c.function.column.Table()
+}
+
+func (c *WindowColumnModifier) ColumnName() string {
+	if c.function.column == nil {
+		return ""
+	}
+	return c.function.column.ColumnName()
+}
+
+func (c *WindowColumnModifier) AliasOrName() string {
+	if c.alias != "" {
+		return c.alias
+	}
+	return c.ColumnName()
+}
+
+func (c *WindowColumnModifier) As(alias string) ColumnField {
+	c.alias = alias
+	return c
+}
+
+func (c *WindowColumnModifier) FieldExpr() string {
+	return compatFieldExpr(c)
+}
+
+func (c *WindowColumnModifier) SQLikeFieldExpr(d dialect.Dialect, bindings *[]interface{}) (string, error) {
+	expr, err := c.SQLikeExpr(d, bindings)
+	if err != nil {
+		return "", err
+	}
+	return aliasExpr(d, expr, c.alias), nil
+}
+
+func (c *WindowColumnModifier) SQLikeExpr(d dialect.Dialect, bindings *[]interface{}) (string, error) {
+	fn, err := c.function.expr(d, bindings)
+	if err != nil {
+		return "", err
+	}
+	clauses := make([]string, 0)
+	for _, clause := range c.clauses {
+		cl, err := clause.windowClause(d, bindings)
+		if err != nil {
+			return "", err
+		}
+		clauses = append(clauses, cl)
+	}
+	return fmt.Sprintf("%s OVER (%s)", fn, strings.Join(clauses, " ")), nil
+}
+
+func (c *WindowColumnModifier) Asc() *SortOrder {
+	return &SortOrder{
+		Column: c,
+		Order:  OrderAsc,
+	}
+}
+
+func (c *WindowColumnModifier) Desc() *SortOrder {
+	return &SortOrder{
+		Column: c,
+		Order:  OrderDesc,
+	}
+}
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/tmarcus87/sqlike/dialect"
+	"testing"
+)
+
+func TestWindowColumnModifier_FieldExpr(t *testing.T) {
+	tbl := NewTable("tbl")
+	c1 := NewInt64Column(tbl, "c1")
+	c2 := NewTextColumn(tbl, "c2")
+
+	tests := []struct {
+		Name   string
+		Field  ColumnField
+		Expect string
+	}{
+		{Name: "RowNumber", Field: RowNumber().Over(OrderBy(c1.Asc())), Expect: "ROW_NUMBER() OVER (ORDER BY `tbl`.`c1` ASC)"},
+		{Name: "Rank", Field: Rank().Over(PartitionBy(c2), OrderBy(c1.Desc())).As("rnk"), Expect: "RANK() OVER (PARTITION BY `tbl`.`c2` ORDER BY `tbl`.`c1` DESC) AS `rnk`"},
+		{Name: "DenseRank", Field: DenseRank().Over(), Expect: "DENSE_RANK() OVER ()"},
+		{Name: "NTile", Field: NTile(4).Over(OrderBy(c1.Asc())), Expect: "NTILE(4) OVER (ORDER BY `tbl`.`c1` ASC)"},
+		{Name: "Lag", Field: Lag(c1, 1).Over(OrderBy(c1.Asc())), Expect: "LAG(`tbl`.`c1`, 1) OVER (ORDER BY `tbl`.`c1` ASC)"},
+		{Name: "Lead", Field: Lead(c1, 2).Over(PartitionBy(c2, c1)), Expect: "LEAD(`tbl`.`c1`, 2) OVER (PARTITION BY `tbl`.`c2`, `tbl`.`c1`)"},
+		{Name: "FirstValue", Field: FirstValue(c2).Over(OrderBy(c1.Asc())), Expect: "FIRST_VALUE(`tbl`.`c2`) OVER (ORDER BY `tbl`.`c1` ASC)"},
+		{
+			Name:   "LastValueWithRows",
+			Field:  LastValue(c2).Over(OrderBy(c1.Asc()), RowsBetween(UnboundedPreceding, UnboundedFollowing)),
+			Expect: "LAST_VALUE(`tbl`.`c2`) OVER (ORDER BY `tbl`.`c1` ASC ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING)",
+		},
+		{
+			Name:   "SumWithRange",
+			Field:  Aggregate(c1.Sum()).Over(PartitionBy(c2), OrderBy(c1.Asc()), RangeBetween(UnboundedPreceding, CurrentRow)),
+			Expect: "SUM(`tbl`.`c1`) OVER (PARTITION BY `tbl`.`c2` ORDER BY `tbl`.`c1` ASC RANGE BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW)",
+		},
+		{
+			Name:   "CountWithRows",
+			Field:  Aggregate(Count(c1)).Over(OrderBy(c1.Asc()), RowsBetween(Preceding(2), Following(1))),
+			Expect: "COUNT(`tbl`.`c1`) OVER (ORDER BY `tbl`.`c1` ASC ROWS BETWEEN 2 PRECEDING AND 1 FOLLOWING)",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			asserts := assert.New(t)
+			asserts.Equal(test.Expect, test.Field.FieldExpr())
+		})
+	}
+}
+
+func TestWindowColumnModifier_SQLikeFieldExpr(t *testing.T) {
+	asserts := assert.New(t)
+
+	tbl := NewTable("tbl")
+	c1 := NewInt64Column(tbl, "c1")
+	c2 := NewTextColumn(tbl, "c2")
+
+	postgres, _ := dialect.Get(dialect.Postgres)
+	bindings := make([]interface{}, 0)
+
+	expr, err :=
+		RowNumber().
+			Over(PartitionBy(Lower(c2)), OrderBy(Coalesce(c1, 0).Desc())).
+			SQLikeFieldExpr(postgres, &bindings)
+	asserts.Nil(err)
+	asserts.Equal(`ROW_NUMBER() OVER (PARTITION BY LOWER("tbl"."c2") ORDER BY COALESCE("tbl"."c1", $1) DESC)`, expr)
+	asserts.Equal([]interface{}{0}, bindings)
+
+	_, err = RowNumber().Over(OrderBy(DateFormat(c2, "%Y").Asc())).SQLikeFieldExpr(&dialect.Standard{DialectName: "standard"}, &bindings)
+	asserts.True(errors.Is(err, dialect.ErrorNotSupported))
+}
+
+func TestWindowColumnModifier_SortOrder(t *testing.T) {
+	asserts := assert.New(t)
+
+	tbl := NewTable("tbl")
+	c1 := NewInt64Column(tbl, "c1")
+
+	w := RowNumber().Over(OrderBy(c1.Desc()))
+	w.As("rn")
+	asserts.Equal("rn", w.AliasOrName())
+	asserts.Equal("ROW_NUMBER() OVER (ORDER BY `tbl`.`c1` DESC)", mysqlColumnExpr(w.Asc().Column))
+	asserts.Equal(OrderDesc, w.Desc().Order)
+}
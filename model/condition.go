@@ -2,6 +2,7 @@ package model
 
 import (
 	"fmt"
+	"github.com/tmarcus87/sqlike/dialect"
 	"strings"
 )
 
@@ -11,6 +12,26 @@ type Condition interface {
 	Or(condition Condition) Condition
 }
 
+// DialectCondition is the Condition which is rendered for the dialect and reports the error such as the failure of the sub query.
+//
+// Statements apply the condition by ApplyWithDialect if it is implemented, and by Apply otherwise.
+// Apply of the conditions in this package renders the condition for MySQL and ignores the error, use ApplyWithDialect instead.
+type DialectCondition interface {
+	Condition
+
+	// ApplyWithDialect appends the condition to stmt and its values to bindings
+	ApplyWithDialect(d dialect.Dialect, stmt *string, bindings *[]interface{}) error
+}
+
+// ApplyCondition applies the condition by ApplyWithDialect if it is a DialectCondition, and by Apply otherwise
+func ApplyCondition(d dialect.Dialect, condition Condition, stmt *string, bindings *[]interface{}) error {
+	if dc, ok := condition.(DialectCondition); ok {
+		return dc.ApplyWithDialect(d, stmt, bindings)
+	}
+	condition.Apply(stmt, bindings)
+	return nil
+}
+
 func And(left, right Condition) Condition {
 	return &AndCondition{
 		left:  left,
@@ -24,7 +45,11 @@ type AndCondition struct {
 }
 
 func (c *AndCondition) Apply(stmt *string, bindings *[]interface{}) {
-	JoinCondition([]Condition{c.left, c.right}, stmt, bindings, "AND")
+	_ = c.ApplyWithDialect(compatDialect(), stmt, bindings)
+}
+
+func (c *AndCondition) ApplyWithDialect(d dialect.Dialect, stmt *string, bindings *[]interface{}) error {
+	return JoinConditionWithDialect(d, []Condition{c.left, c.right}, stmt, bindings, "AND")
 }
 
 func (c *AndCondition) And(condition Condition) Condition {
@@ -54,7 +79,11 @@ type OrCondition struct {
 }
 
 func (c *OrCondition) Apply(stmt *string, bindings *[]interface{}) {
-	JoinCondition([]Condition{c.left, c.right}, stmt, bindings, "OR")
+	_ = c.ApplyWithDialect(compatDialect(), stmt, bindings)
+}
+
+func (c *OrCondition) ApplyWithDialect(d dialect.Dialect, stmt *string, bindings *[]interface{}) error {
+	return JoinConditionWithDialect(d, []Condition{c.left, c.right}, stmt, bindings, "OR")
 }
 
 func (c *OrCondition) And(condition Condition) Condition {
@@ -72,12 +101,18 @@ func (c *OrCondition) Or(condition Condition) Condition {
 }
 
 func JoinCondition(conditions []Condition, stmt *string, bindings *[]interface{}, operator string) {
+	_ = JoinConditionWithDialect(compatDialect(), conditions, stmt, bindings, operator)
+}
+
+// JoinConditionWithDialect joins the conditions by the operator, the conditions are applied by ApplyCondition
+func JoinConditionWithDialect(d dialect.Dialect, conditions []Condition, stmt *string, bindings *[]interface{}, operator string) error {
 	statements := make([]string, 0)
-	b := make([]interface{}, 0)
 
 	for _, condition := range conditions {
 		statement := ""
-		condition.Apply(&statement, &b)
+		if err := ApplyCondition(d, condition, &statement, bindings); err != nil {
+			return err
+		}
 		statements = append(statements, statement)
 	}
 
@@ -91,7 +126,7 @@ func JoinCondition(conditions []Condition, stmt *string, bindings *[]interface{}
 		*stmt += ")"
 	}
 
-	*bindings = append(*bindings, b...)
+	return nil
 }
 
 type NoValueCondition struct {
@@ -100,7 +135,16 @@ type NoValueCondition struct {
 }
 
 func (c *NoValueCondition) Apply(stmt *string, bindings *[]interface{}) {
-	*stmt += fmt.Sprintf("`%s`.`%s` %s", c.Column.Table().SQLikeAliasOrName(), c.Column.ColumnName(), c.Operator)
+	_ = c.ApplyWithDialect(compatDialect(), stmt, bindings)
+}
+
+func (c *NoValueCondition) ApplyWithDialect(d dialect.Dialect, stmt *string, bindings *[]interface{}) error {
+	column, err := ColumnExpr(d, c.Column, bindings)
+	if err != nil {
+		return err
+	}
+	*stmt += fmt.Sprintf("%s %s", column, c.Operator)
+	return nil
 }
 
 func (c *NoValueCondition) And(condition Condition) Condition {
@@ -124,8 +168,16 @@ type SingleValueCondition struct {
 }
 
 func (c *SingleValueCondition) Apply(stmt *string, bindings *[]interface{}) {
-	*stmt += fmt.Sprintf("`%s`.`%s` %s ?", c.Column.Table().SQLikeAliasOrName(), c.Column.ColumnName(), c.Operator)
-	*bindings = append(*bindings, c.Value)
+	_ = c.ApplyWithDialect(compatDialect(), stmt, bindings)
+}
+
+func (c *SingleValueCondition) ApplyWithDialect(d dialect.Dialect, stmt *string, bindings *[]interface{}) error {
+	column, err := ColumnExpr(d, c.Column, bindings)
+	if err != nil {
+		return err
+	}
+	*stmt += fmt.Sprintf("%s %s %s", column, c.Operator, bind(d, bindings, c.Value))
+	return nil
 }
 
 func (c *SingleValueCondition) And(condition Condition) Condition {
@@ -149,17 +201,26 @@ type MultiValueCondition struct {
 }
 
 func (c *MultiValueCondition) Apply(stmt *string, bindings *[]interface{}) {
+	_ = c.ApplyWithDialect(compatDialect(), stmt, bindings)
+}
+
+func (c *MultiValueCondition) ApplyWithDialect(d dialect.Dialect, stmt *string, bindings *[]interface{}) error {
+	column, err := ColumnExpr(d, c.Column, bindings)
+	if err != nil {
+		return err
+	}
+
 	conds := make([]string, 0)
-	for i := 0; i < len(c.Values); i++ {
-		conds = append(conds, "?")
+	for _, value := range c.Values {
+		conds = append(conds, bind(d, bindings, value))
 	}
 
 	*stmt +=
-		fmt.Sprintf("`%s`.`%s` %s (%s)",
-			c.Column.Table().SQLikeAliasOrName(), c.Column.ColumnName(),
+		fmt.Sprintf("%s %s (%s)",
+			column,
 			c.Operator,
 			strings.Join(conds, ", "))
-	*bindings = append(*bindings, c.Values...)
+	return nil
 }
 
 func (c *MultiValueCondition) And(condition Condition) Condition {
@@ -183,10 +244,20 @@ type SingleColumnCondition struct {
 }
 
 func (c *SingleColumnCondition) Apply(stmt *string, bindings *[]interface{}) {
-	*stmt += fmt.Sprintf("`%s`.`%s` %s `%s`.`%s`",
-		c.Column.Table().SQLikeAliasOrName(), c.Column.ColumnName(),
-		c.Operator,
-		c.Value.Table().SQLikeAliasOrName(), c.Value.ColumnName())
+	_ = c.ApplyWithDialect(compatDialect(), stmt, bindings)
+}
+
+func (c *SingleColumnCondition) ApplyWithDialect(d dialect.Dialect, stmt *string, bindings *[]interface{}) error {
+	column, err := ColumnExpr(d, c.Column, bindings)
+	if err != nil {
+		return err
+	}
+	value, err := ColumnExpr(d, c.Value, bindings)
+	if err != nil {
+		return err
+	}
+	*stmt += fmt.Sprintf("%s %s %s", column, c.Operator, value)
+	return nil
 }
 
 func (c *SingleColumnCondition) And(condition Condition) Condition {
@@ -202,3 +273,49 @@ func (c *SingleColumnCondition) Or(condition Condition) Condition {
 		right: condition,
 	}
 }
+
+type RowValueCondition struct {
+	Columns  []ColumnField
+	Operator string
+	Values   []interface{}
+}
+
+func (c *RowValueCondition) Apply(stmt *string, bindings *[]interface{}) {
+	_ = c.ApplyWithDialect(compatDialect(), stmt, bindings)
+}
+
+func (c *RowValueCondition) ApplyWithDialect(d dialect.Dialect, stmt *string, bindings *[]interface{}) error {
+	cols := make([]string, 0)
+	conds := make([]string, 0)
+	for _, column := range c.Columns {
+		col, err := ColumnExpr(d, column, bindings)
+		if err != nil {
+			return err
+		}
+		cols = append(cols, col)
+	}
+	for _, value := range c.Values {
+		conds = append(conds, bind(d, bindings, value))
+	}
+
+	*stmt +=
+		fmt.Sprintf("(%s) %s (%s)",
+			strings.Join(cols, ", "),
+			c.Operator,
+			strings.Join(conds, ", "))
+	return nil
+}
+
+func (c *RowValueCondition) And(condition Condition) Condition {
+	return &AndCondition{
+		left:  c,
+		right: condition,
+	}
+}
+
+func (c *RowValueCondition) Or(condition Condition) Condition {
+	return &OrCondition{
+		left:  c,
+		right: condition,
+	}
+}
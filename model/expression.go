@@ -0,0 +1,377 @@
+package model
+
+import (
+	"fmt"
+	"github.com/tmarcus87/sqlike/dialect"
+	"strings"
+)
+
+// operandExpr 式はそのまま、カラムはカラム名、それ以外の値はバインド変数として出力します
+func operandExpr(d dialect.Dialect, v interface{}, bindings *[]interface{}) (string, error) {
+	if c, ok := v.(Column); ok {
+		return ColumnExpr(d, c, bindings)
+	}
+	return bind(d, bindings, v), nil
+}
+
+func operandExprs(d dialect.Dialect, vs []interface{}, bindings *[]interface{}) ([]string, error) {
+	exprs := make([]string, 0)
+	for _, v := range vs {
+		expr, err := operandExpr(d, v, bindings)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+	return exprs, nil
+}
+
+// function returns the expression of the function whose arguments are the operands
+func function(name string, args ...interface{}) *Expr {
+	return &Expr{
+		render: func(d dialect.Dialect, bindings *[]interface{}) (string, error) {
+			exprs, err := operandExprs(d, args, bindings)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%s(%s)", name, strings.Join(exprs, ", ")), nil
+		},
+	}
+}
+
+// Expr is the expression which can be used as the field, the condition, the order and the value to update.
+//
+// The operands of the expression are the value which is bound as the parameter, the column and the expression.
+type Expr struct {
+	render func(d dialect.Dialect, bindings *[]interface{}) (string, error)
+	alias  string
+}
+
+func (e *Expr) Table() Table {
+	return nil
+}
+
+func (e *Expr) ColumnName() string {
+	return e.alias
+}
+
+func (e *Expr) AliasOrName() string {
+	return e.alias
+}
+
+func (e *Expr) As(alias string) ColumnField {
+	e.alias = alias
+	return e
+}
+
+// FieldExpr returns the expression of MySQL without the bindings, use SQLikeFieldExpr instead
+func (e *Expr) FieldExpr() string {
+	return compatFieldExpr(e)
+}
+
+func (e *Expr) SQLikeExpr(d dialect.Dialect, bindings *[]interface{}) (string, error) {
+	return e.render(d, bindings)
+}
+
+func (e *Expr) SQLikeFieldExpr(d dialect.Dialect, bindings *[]interface{}) (string, error) {
+	expr, err := e.SQLikeExpr(d, bindings)
+	if err != nil {
+		return "", err
+	}
+	return aliasExpr(d, expr, e.alias), nil
+}
+
+func (e *Expr) operator(operator string, v interface{}) *Expr {
+	return &Expr{
+		render: func(d dialect.Dialect, bindings *[]interface{}) (string, error) {
+			exprs, err := operandExprs(d, []interface{}{e, v}, bindings)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("(%s %s %s)", exprs[0], operator, exprs[1]), nil
+		},
+	}
+}
+
+func (e *Expr) Plus(v interface{}) *Expr {
+	return e.operator("+", v)
+}
+
+func (e *Expr) Minus(v interface{}) *Expr {
+	return e.operator("-", v)
+}
+
+func (e *Expr) Multiply(v interface{}) *Expr {
+	return e.operator("*", v)
+}
+
+func (e *Expr) Divide(v interface{}) *Expr {
+	return e.operator("/", v)
+}
+
+func (e *Expr) Eq(v interface{}) Condition {
+	return &ExpressionCondition{Left: e, Operator: "=", Right: []interface{}{v}}
+}
+
+func (e *Expr) NotEq(v interface{}) Condition {
+	return &ExpressionCondition{Left: e, Operator: "!=", Right: []interface{}{v}}
+}
+
+func (e *Expr) Gt(v interface{}) Condition {
+	return &ExpressionCondition{Left: e, Operator: ">", Right: []interface{}{v}}
+}
+
+func (e *Expr) GtOrEq(v interface{}) Condition {
+	return &ExpressionCondition{Left: e, Operator: ">=", Right: []interface{}{v}}
+}
+
+func (e *Expr) Lt(v interface{}) Condition {
+	return &ExpressionCondition{Left: e, Operator: "<", Right: []interface{}{v}}
+}
+
+func (e *Expr) LtOrEq(v interface{}) Condition {
+	return &ExpressionCondition{Left: e, Operator: "<=", Right: []interface{}{v}}
+}
+
+func (e *Expr) Like(v interface{}) Condition {
+	return &ExpressionCondition{Left: e, Operator: "LIKE", Right: []interface{}{v}}
+}
+
+func (e *Expr) In(vs ...interface{}) Condition {
+	return &ExpressionCondition{Left: e, Operator: "IN", Right: vs}
+}
+
+func (e *Expr) NotIn(vs ...interface{}) Condition {
+	return &ExpressionCondition{Left: e, Operator: "NOT IN", Right: vs}
+}
+
+func (e *Expr) IsNull() Condition {
+	return &ExpressionCondition{Left: e, Operator: "IS NULL"}
+}
+
+func (e *Expr) IsNotNull() Condition {
+	return &ExpressionCondition{Left: e, Operator: "IS NOT NULL"}
+}
+
+func (e *Expr) Asc() *SortOrder {
+	return &SortOrder{
+		Column: e,
+		Order:  OrderAsc,
+	}
+}
+
+func (e *Expr) Desc() *SortOrder {
+	return &SortOrder{
+		Column: e,
+		Order:  OrderDesc,
+	}
+}
+
+// ColumnOf returns the column as the expression such as `ColumnOf(counter).Plus(1)`
+func ColumnOf(column Column) *Expr {
+	return &Expr{
+		render: func(d dialect.Dialect, bindings *[]interface{}) (string, error) {
+			return ColumnExpr(d, column, bindings)
+		},
+	}
+}
+
+// InsertedValue returns the value which is proposed for insertion in OnDuplicateKeyUpdate,
+// which is new.column(row alias) in MySQL and excluded.column in PostgreSQL and SQLite.
+func InsertedValue(column Column) *Expr {
+	return &Expr{
+		render: func(d dialect.Dialect, _ *[]interface{}) (string, error) {
+			return d.InsertedValue(d.QuoteIdentifier(column.ColumnName()))
+		},
+	}
+}
+
+// Coalesce returns COALESCE(args...), which is the first non-null argument
+func Coalesce(args ...interface{}) *Expr {
+	return function("COALESCE", args...)
+}
+
+// IfNull returns the alternative if v is null, which is rendered as COALESCE(v, alternative) for all dialects
+func IfNull(v, alternative interface{}) *Expr {
+	return function("COALESCE", v, alternative)
+}
+
+// Lower returns LOWER(v)
+func Lower(v interface{}) *Expr {
+	return function("LOWER", v)
+}
+
+// Upper returns UPPER(v)
+func Upper(v interface{}) *Expr {
+	return function("UPPER", v)
+}
+
+// Concat returns the concatenation of the arguments, which is CONCAT in MySQL and || in other dialects
+func Concat(args ...interface{}) *Expr {
+	return &Expr{
+		render: func(d dialect.Dialect, bindings *[]interface{}) (string, error) {
+			exprs, err := operandExprs(d, args, bindings)
+			if err != nil {
+				return "", err
+			}
+			return d.Concat(exprs...), nil
+		},
+	}
+}
+
+// DateFormat returns the formatted date, the format depends on the dialect
+// such as DATE_FORMAT in MySQL, TO_CHAR in PostgreSQL and STRFTIME in SQLite.
+func DateFormat(v interface{}, format string) *Expr {
+	return &Expr{
+		render: func(d dialect.Dialect, bindings *[]interface{}) (string, error) {
+			expr, err := operandExpr(d, v, bindings)
+			if err != nil {
+				return "", err
+			}
+			return d.DateFormat(expr, format)
+		},
+	}
+}
+
+// Now returns the current timestamp, which is rendered as CURRENT_TIMESTAMP for all dialects
+func Now() *Expr {
+	return &Expr{
+		render: func(dialect.Dialect, *[]interface{}) (string, error) {
+			return "CURRENT_TIMESTAMP", nil
+		},
+	}
+}
+
+// Cast returns CAST(v AS dataType)
+func Cast(v interface{}, dataType string) *Expr {
+	return &Expr{
+		render: func(d dialect.Dialect, bindings *[]interface{}) (string, error) {
+			expr, err := operandExpr(d, v, bindings)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("CAST(%s AS %s)", expr, dataType), nil
+		},
+	}
+}
+
+type caseWhen struct {
+	condition Condition
+	then      interface{}
+}
+
+// CaseExpr is the builder of `CASE WHEN condition THEN v ... ELSE v END`
+type CaseExpr struct {
+	whens   []caseWhen
+	els     interface{}
+	hasElse bool
+}
+
+// CaseWhen starts CASE expression
+func CaseWhen(condition Condition, then interface{}) *CaseExpr {
+	return (&CaseExpr{}).When(condition, then)
+}
+
+func (c *CaseExpr) When(condition Condition, then interface{}) *CaseExpr {
+	c.whens = append(c.whens, caseWhen{condition: condition, then: then})
+	return c
+}
+
+func (c *CaseExpr) Else(v interface{}) *CaseExpr {
+	c.els = v
+	c.hasElse = true
+	return c
+}
+
+// End returns CASE expression
+func (c *CaseExpr) End() *Expr {
+	return &Expr{render: c.render}
+}
+
+func (c *CaseExpr) render(d dialect.Dialect, bindings *[]interface{}) (string, error) {
+	expr := "CASE"
+	for _, when := range c.whens {
+		cond := ""
+		if err := ApplyCondition(d, when.condition, &cond, bindings); err != nil {
+			return "", err
+		}
+		then, err := operandExpr(d, when.then, bindings)
+		if err != nil {
+			return "", err
+		}
+		expr += fmt.Sprintf(" WHEN %s THEN %s", cond, then)
+	}
+	if c.hasElse {
+		els, err := operandExpr(d, c.els, bindings)
+		if err != nil {
+			return "", err
+		}
+		expr += " ELSE " + els
+	}
+	return expr + " END", nil
+}
+
+// ExpressionCondition is the condition which compares the expression with the operands
+type ExpressionCondition struct {
+	Left     Expression
+	Operator string
+	Right    []interface{}
+}
+
+func (c *ExpressionCondition) Apply(stmt *string, bindings *[]interface{}) {
+	_ = c.ApplyWithDialect(compatDialect(), stmt, bindings)
+}
+
+func (c *ExpressionCondition) ApplyWithDialect(d dialect.Dialect, stmt *string, bindings *[]interface{}) error {
+	left, err := c.Left.SQLikeExpr(d, bindings)
+	if err != nil {
+		return err
+	}
+	right, err := operandExprs(d, c.Right, bindings)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case len(c.Right) == 0:
+		*stmt += fmt.Sprintf("%s %s", left, c.Operator)
+	case c.Operator == "IN" || c.Operator == "NOT IN":
+		*stmt += fmt.Sprintf("%s %s (%s)", left, c.Operator, strings.Join(right, ", "))
+	default:
+		*stmt += fmt.Sprintf("%s %s %s", left, c.Operator, right[0])
+	}
+	return nil
+}
+
+func (c *ExpressionCondition) And(condition Condition) Condition {
+	return &AndCondition{
+		left:  c,
+		right: condition,
+	}
+}
+
+func (c *ExpressionCondition) Or(condition Condition) Condition {
+	return &OrCondition{
+		left:  c,
+		right: condition,
+	}
+}
+
+// ExpressionValue is the ColumnValue whose value is the expression, which is used to update the column
+type ExpressionValue struct {
+	Column
+	Expression Expression
+}
+
+// ExprValue returns the ColumnValue to update the column by the expression
+func ExprValue(column Column, expr Expression) *ExpressionValue {
+	return &ExpressionValue{Column: column, Expression: expr}
+}
+
+func (v *ExpressionValue) FieldExpr() string {
+	return compatDialect().QuoteIdentifier(v.ColumnName())
+}
+
+func (v *ExpressionValue) ColumnValue() interface{} {
+	return v.Expression
+}
@@ -0,0 +1,122 @@
+package model
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/tmarcus87/sqlike/dialect"
+	"testing"
+)
+
+func TestExpr_SQLikeExpr(t *testing.T) {
+	tbl := NewTable("tbl")
+	c1 := NewInt64Column(tbl, "c1")
+	c2 := NewTextColumn(tbl, "c2")
+	c3 := NewTimeColumn(tbl, "c3")
+
+	mysql, _ := dialect.Get(dialect.MySQL)
+	sqlite3, _ := dialect.Get(dialect.Sqlite3)
+	postgres, _ := dialect.Get(dialect.Postgres)
+
+	tests := []struct {
+		Name     string
+		Dialect  dialect.Dialect
+		Expr     Expression
+		Expect   string
+		Bindings []interface{}
+	}{
+		{
+			Name:     "Case",
+			Dialect:  mysql,
+			Expr:     CaseWhen(c1.Gt(10), "large").When(c1.Gt(5), c2).Else("small").End(),
+			Expect:   "CASE WHEN `tbl`.`c1` > ? THEN ? WHEN `tbl`.`c1` > ? THEN `tbl`.`c2` ELSE ? END",
+			Bindings: []interface{}{int64(10), "large", int64(5), "small"},
+		},
+		{
+			Name:     "CaseWithoutElse",
+			Dialect:  mysql,
+			Expr:     CaseWhen(c2.IsNull(), 0).End(),
+			Expect:   "CASE WHEN `tbl`.`c2` IS NULL THEN ? END",
+			Bindings: []interface{}{0},
+		},
+		{Name: "Coalesce", Dialect: mysql, Expr: Coalesce(c2, c1, "none"), Expect: "COALESCE(`tbl`.`c2`, `tbl`.`c1`, ?)", Bindings: []interface{}{"none"}},
+		{Name: "IfNull", Dialect: postgres, Expr: IfNull(c1, 0), Expect: `COALESCE("tbl"."c1", $1)`, Bindings: []interface{}{0}},
+		{Name: "LowerUpper", Dialect: mysql, Expr: Lower(Upper(c2)), Expect: "LOWER(UPPER(`tbl`.`c2`))", Bindings: []interface{}{}},
+		{Name: "ConcatMySQL", Dialect: mysql, Expr: Concat(c2, "-", c1), Expect: "CONCAT(`tbl`.`c2`, ?, `tbl`.`c1`)", Bindings: []interface{}{"-"}},
+		{Name: "ConcatSQLite", Dialect: sqlite3, Expr: Concat(c2, "-", c1), Expect: `("tbl"."c2" || ? || "tbl"."c1")`, Bindings: []interface{}{"-"}},
+		{Name: "DateFormatMySQL", Dialect: mysql, Expr: DateFormat(c3, "%Y-%m"), Expect: "DATE_FORMAT(`tbl`.`c3`, '%Y-%m')"},
+		{Name: "DateFormatPostgres", Dialect: postgres, Expr: DateFormat(c3, "YYYY-MM"), Expect: `TO_CHAR("tbl"."c3", 'YYYY-MM')`},
+		{Name: "DateFormatSQLite", Dialect: sqlite3, Expr: DateFormat(c3, "%Y-%m"), Expect: `STRFTIME('%Y-%m', "tbl"."c3")`},
+		{Name: "Now", Dialect: mysql, Expr: Now(), Expect: "CURRENT_TIMESTAMP"},
+		{Name: "Cast", Dialect: mysql, Expr: Cast(c2, "CHAR(10)"), Expect: "CAST(`tbl`.`c2` AS CHAR(10))"},
+		{
+			Name:     "Arithmetic",
+			Dialect:  mysql,
+			Expr:     Coalesce(c1, 0).Plus(1).Multiply(c1),
+			Expect:   "((COALESCE(`tbl`.`c1`, ?) + ?) * `tbl`.`c1`)",
+			Bindings: []interface{}{0, 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			asserts := assert.New(t)
+
+			bindings := make([]interface{}, 0)
+			expr, err := test.Expr.SQLikeExpr(test.Dialect, &bindings)
+			asserts.Nil(err)
+			asserts.Equal(test.Expect, expr)
+			if test.Bindings == nil {
+				asserts.Empty(bindings)
+			} else {
+				asserts.Equal(test.Bindings, bindings)
+			}
+		})
+	}
+
+	t.Run("NotSupported", func(t *testing.T) {
+		d := &dialect.Standard{DialectName: "standard"}
+		_, err := Concat("a", DateFormat(c3, "%Y")).SQLikeExpr(d, &[]interface{}{})
+		assert.True(t, errors.Is(err, dialect.ErrorNotSupported))
+	})
+}
+
+func TestExpressionCondition_Apply(t *testing.T) {
+	tbl := NewTable("tbl")
+	c1 := NewInt64Column(tbl, "c1")
+	c2 := NewTextColumn(tbl, "c2")
+
+	mysql, _ := dialect.Get(dialect.MySQL)
+
+	tests := []struct {
+		Name     string
+		Cond     Condition
+		Expect   string
+		Bindings []interface{}
+	}{
+		{Name: "Eq", Cond: Lower(c2).Eq("foo"), Expect: "LOWER(`tbl`.`c2`) = ?", Bindings: []interface{}{"foo"}},
+		{Name: "EqColumn", Cond: Lower(c2).Eq(c2), Expect: "LOWER(`tbl`.`c2`) = `tbl`.`c2`", Bindings: []interface{}{}},
+		{Name: "Gt", Cond: IfNull(c1, 0).Plus(1).Gt(10), Expect: "(COALESCE(`tbl`.`c1`, ?) + ?) > ?", Bindings: []interface{}{0, 1, 10}},
+		{Name: "In", Cond: Upper(c2).In("A", "B"), Expect: "UPPER(`tbl`.`c2`) IN (?, ?)", Bindings: []interface{}{"A", "B"}},
+		{Name: "IsNull", Cond: Coalesce(c1, c2).IsNull(), Expect: "COALESCE(`tbl`.`c1`, `tbl`.`c2`) IS NULL", Bindings: []interface{}{}},
+		{
+			Name:     "And",
+			Cond:     Concat(c2, "!").Like("%!").And(c1.Eq(1)),
+			Expect:   "(CONCAT(`tbl`.`c2`, ?) LIKE ? AND `tbl`.`c1` = ?)",
+			Bindings: []interface{}{"!", "%!", int64(1)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var (
+				stmt     string
+				bindings = make([]interface{}, 0)
+			)
+
+			asserts := assert.New(t)
+			asserts.Nil(ApplyCondition(mysql, test.Cond, &stmt, &bindings))
+			asserts.Equal(test.Expect, stmt)
+			asserts.Equal(test.Bindings, bindings)
+		})
+	}
+}
@@ -1,5 +1,10 @@
 package model
 
+import (
+	"fmt"
+	"github.com/tmarcus87/sqlike/dialect"
+)
+
 const (
 	OrderAsc  = "ASC"
 	OrderDesc = "DESC"
@@ -9,3 +14,24 @@ type SortOrder struct {
 	Column ColumnField
 	Order  string
 }
+
+// OrderExpr returns the order like "`t1`.`c1` ASC" for the dialect, and appends the values of the expression to bindings
+func OrderExpr(d dialect.Dialect, order *SortOrder, bindings *[]interface{}) (string, error) {
+	expr, err := ColumnExpr(d, order.Column, bindings)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s %s", expr, order.Order), nil
+}
+
+func orderExprs(d dialect.Dialect, orders []*SortOrder, bindings *[]interface{}) ([]string, error) {
+	exprs := make([]string, 0)
+	for _, order := range orders {
+		expr, err := OrderExpr(d, order, bindings)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+	return exprs, nil
+}
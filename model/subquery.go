@@ -0,0 +1,108 @@
+package model
+
+import (
+	"fmt"
+	"github.com/tmarcus87/sqlike/dialect"
+)
+
+// SubQuery is the statement which can be embedded into other statement
+// such as `IN (SELECT ...)`, `EXISTS (SELECT ...)` and `FROM (SELECT ...) AS t`.
+//
+// statement.Statement implements SubQuery.
+type SubQuery interface {
+	// SQLikeSubQuery returns the statement for the dialect, whose placeholders are numbered after bindings,
+	// and appends its bindings to bindings
+	SQLikeSubQuery(d dialect.Dialect, bindings *[]interface{}) (string, error)
+}
+
+type SubQueryCondition struct {
+	// Column is nil for EXISTS and NOT EXISTS
+	Column   ColumnField
+	Operator string
+	SubQuery SubQuery
+}
+
+func (c *SubQueryCondition) Apply(stmt *string, bindings *[]interface{}) {
+	_ = c.ApplyWithDialect(compatDialect(), stmt, bindings)
+}
+
+func (c *SubQueryCondition) ApplyWithDialect(d dialect.Dialect, stmt *string, bindings *[]interface{}) error {
+	if c.Column != nil {
+		column, err := ColumnExpr(d, c.Column, bindings)
+		if err != nil {
+			return err
+		}
+		*stmt += column + " "
+	}
+
+	sq, err := c.SubQuery.SQLikeSubQuery(d, bindings)
+	if err != nil {
+		return fmt.Errorf("failed to build sub query : %w", err)
+	}
+	*stmt += fmt.Sprintf("%s (%s)", c.Operator, sq)
+	return nil
+}
+
+func (c *SubQueryCondition) And(condition Condition) Condition {
+	return &AndCondition{
+		left:  c,
+		right: condition,
+	}
+}
+
+func (c *SubQueryCondition) Or(condition Condition) Condition {
+	return &OrCondition{
+		left:  c,
+		right: condition,
+	}
+}
+
+// Exists returns the condition `EXISTS (sub query)`
+func Exists(sq SubQuery) Condition {
+	return &SubQueryCondition{Operator: "EXISTS", SubQuery: sq}
+}
+
+// NotExists returns the condition `NOT EXISTS (sub query)`
+func NotExists(sq SubQuery) Condition {
+	return &SubQueryCondition{Operator: "NOT EXISTS", SubQuery: sq}
+}
+
+// DerivedTable is the table derived from the sub query, which is `(SELECT ...) AS alias`
+type DerivedTable struct {
+	subQuery SubQuery
+	alias    string
+}
+
+func NewDerivedTable(sq SubQuery, alias string) *DerivedTable {
+	return &DerivedTable{
+		subQuery: sq,
+		alias:    alias,
+	}
+}
+
+func (t *DerivedTable) SQLikeTableName() string {
+	return t.alias
+}
+
+func (t *DerivedTable) SQLikeAliasOrName() string {
+	return t.alias
+}
+
+// SQLikeTableExpr returns the table expression of MySQL without the bindings, which is empty if the sub query fails.
+// Statements use SQLikeDialectTableExpr, which reports the error of the sub query on build.
+func (t *DerivedTable) SQLikeTableExpr() string {
+	bindings := make([]interface{}, 0)
+	expr, err := t.SQLikeDialectTableExpr(compatDialect(), &bindings)
+	if err != nil {
+		return ""
+	}
+	return expr
+}
+
+func (t *DerivedTable) SQLikeDialectTableExpr(d dialect.Dialect, bindings *[]interface{}) (string, error) {
+	sq, err := t.subQuery.SQLikeSubQuery(d, bindings)
+	if err != nil {
+		return "", fmt.Errorf("failed to build sub query : %w", err)
+	}
+	return aliasExpr(d, "("+sq+")", t.alias), nil
+}
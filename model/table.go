@@ -1,6 +1,6 @@
 package model
 
-import "fmt"
+import "github.com/tmarcus87/sqlike/dialect"
 
 type Table interface {
 	// テーブル名を返します
@@ -11,6 +11,27 @@ type Table interface {
 	SQLikeTableExpr() string
 }
 
+// DialectTable is the Table which is rendered for the dialect of the statement such as the derived table
+type DialectTable interface {
+	Table
+
+	// SQLikeDialectTableExpr returns the table expression for the dialect, and appends its values to bindings
+	SQLikeDialectTableExpr(d dialect.Dialect, bindings *[]interface{}) (string, error)
+}
+
+// TableExpr returns the table expression with the alias for the dialect.
+// The table which is not a DialectTable is rendered by its name and alias.
+func TableExpr(d dialect.Dialect, t Table, bindings *[]interface{}) (string, error) {
+	if dt, ok := t.(DialectTable); ok {
+		return dt.SQLikeDialectTableExpr(d, bindings)
+	}
+	expr := d.QuoteIdentifier(t.SQLikeTableName())
+	if alias := t.SQLikeAliasOrName(); alias != t.SQLikeTableName() {
+		expr = aliasExpr(d, expr, alias)
+	}
+	return expr, nil
+}
+
 type BasicTable struct {
 	Name  string
 	alias string
@@ -27,11 +48,9 @@ func (t *BasicTable) SQLikeAliasOrName() string {
 	return t.Name
 }
 
+// SQLikeTableExpr returns the expression of MySQL, statements use TableExpr instead
 func (t *BasicTable) SQLikeTableExpr() string {
-	expr := fmt.Sprintf("`%s`", t.Name)
-	if t.alias != "" {
-		expr = fmt.Sprintf("%s AS `%s`", expr, t.alias)
-	}
+	expr, _ := TableExpr(compatDialect(), t, nil)
 	return expr
 }
 
@@ -18,11 +18,27 @@ type SQLSession interface {
 	Update(table model.Table) statement.UpdateBranchStep
 	DeleteFrom(table model.Table) statement.DeleteFromBranchStep
 	Truncate(table model.Table) statement.TruncateBranchStep
+
+	// Upsert inserts the records and updates the rows which conflict with the keys
+	Upsert(table model.Table) statement.UpsertBranchStep
+
+	// With defines the common table expression `WITH name AS (query)`
+	With(name string, query model.SubQuery, columns ...string) statement.WithBranchStep
+
+	// WithRecursive defines the recursive common table expression `WITH RECURSIVE name AS (query)`
+	WithRecursive(name string, query model.SubQuery, columns ...string) statement.WithBranchStep
 }
 
 type Session interface {
+	// Begin begins transaction with the default options
 	Begin() (TxSession, error)
 
+	// BeginTx begins transaction with the options
+	//
+	// The transaction is bound to the context of the session and rolled back when the context is done.
+	// Readonly session can begin only read-only transaction.
+	BeginTx(opts *sql.TxOptions) (TxSession, error)
+
 	SQLSession
 }
 
@@ -31,40 +47,68 @@ type basicSession struct {
 	ctx      context.Context
 	dialect  string
 	readonly bool
+
+	// allowWriteWithoutWhere 条件のないUPDATEとDELETEを許可する
+	allowWriteWithoutWhere bool
 }
 
-func NewSession(ctx context.Context, db *sql.DB, dialect string, readonly bool) Session {
-	return &basicSession{
+type Option func(s *basicSession)
+
+// AllowWriteWithoutWhere allows UPDATE and DELETE without WHERE even if AllRows is not called
+func AllowWriteWithoutWhere() Option {
+	return func(s *basicSession) {
+		s.allowWriteWithoutWhere = true
+	}
+}
+
+func NewSession(ctx context.Context, db *sql.DB, dialect string, readonly bool, opts ...Option) Session {
+	s := &basicSession{
 		db:       db,
 		ctx:      ctx,
 		dialect:  dialect,
 		readonly: readonly,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 func (s *basicSession) Begin() (TxSession, error) {
-	if s.readonly {
+	return s.BeginTx(nil)
+}
+
+func (s *basicSession) BeginTx(opts *sql.TxOptions) (TxSession, error) {
+	if s.readonly && (opts == nil || !opts.ReadOnly) {
 		return nil, ErrorReadonlySession
 	}
 
-	tx, err := s.db.Begin()
+	tx, err := s.db.BeginTx(s.ctx, opts)
 	if err != nil {
 		return nil, err
 	}
 
 	return &basicTxSession{
-		tx:      tx,
-		ctx:     s.ctx,
-		dialect: s.dialect,
+		tx:                     tx,
+		ctx:                    s.ctx,
+		dialect:                s.dialect,
+		allowWriteWithoutWhere: s.allowWriteWithoutWhere,
 	}, nil
 }
 
 func (s *basicSession) rootStep() *statement.RootStep {
-	return statement.NewRootStep(
+	// 未登録のdialectの場合はStatementの組み立て時にエラーとなる
+	d, _ := dialect.Get(s.dialect)
+	root := statement.NewRootStep(
 		s.ctx,
-		dialect.GetDialectStatements(s.dialect),
+		d,
 		s.db.QueryContext,
-		s.db.ExecContext)
+		s.db.ExecContext).
+		WithTxBeginner(s.db.BeginTx)
+	if s.allowWriteWithoutWhere {
+		root.AllowWriteWithoutWhere()
+	}
+	return root
 }
 
 func (s *basicSession) Explain() statement.ExplainSelectBranchStep {
@@ -91,6 +135,10 @@ func (s *basicSession) InsertInto(table model.Table) statement.InsertIntoBranchS
 	return statement.NewInsertIntoBranchStep(s.rootStep(), table)
 }
 
+func (s *basicSession) Upsert(table model.Table) statement.UpsertBranchStep {
+	return statement.NewUpsertBranchStep(s.rootStep(), table)
+}
+
 func (s *basicSession) Update(table model.Table) statement.UpdateBranchStep {
 	return statement.NewUpdateBranchStep(s.rootStep(), table)
 }
@@ -102,3 +150,11 @@ func (s *basicSession) DeleteFrom(table model.Table) statement.DeleteFromBranchS
 func (s *basicSession) Truncate(table model.Table) statement.TruncateBranchStep {
 	return statement.NewTruncateBranchStep(s.rootStep(), table)
 }
+
+func (s *basicSession) With(name string, query model.SubQuery, columns ...string) statement.WithBranchStep {
+	return statement.NewWithBranchStep(s.rootStep(), name, query, columns...)
+}
+
+func (s *basicSession) WithRecursive(name string, query model.SubQuery, columns ...string) statement.WithBranchStep {
+	return statement.NewWithRecursiveBranchStep(s.rootStep(), name, query, columns...)
+}
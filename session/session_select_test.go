@@ -3,11 +3,12 @@ package session
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	_ "github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 	"github.com/tmarcus87/sqlike/dialect"
 	"github.com/tmarcus87/sqlike/model"
+	"github.com/tmarcus87/sqlike/statement"
 	"testing"
 )
 
@@ -17,27 +18,12 @@ type Author struct {
 }
 
 func TestFetchMap(t *testing.T) {
-	authorTable := model.NewTable("author")
-
-	authorIdColumn := model.NewInt64Column(authorTable, "id")
-	authorNameColumn := model.NewTextColumn(authorTable, "name")
-
-	db, err := sql.Open(dialect.MySQL, "user:password@tcp(127.0.0.1:3306)/sqlike")
-	if err != nil {
-		panic(err)
-	}
-	defer db.Close()
-
 	asserts := assert.New(t)
 
-	if _, err := db.Exec("TRUNCATE `author`"); err != nil {
-		panic(err)
-	}
-	if _, err := db.Exec("INSERT INTO `author` (`name`) VALUES ('William Shakespeare'), ('J. K. Rowling')"); err != nil {
-		panic(err)
-	}
+	db := openTestDB(t)
+	defer db.Close()
 
-	s := NewSession(context.Background(), db, dialect.MySQL, false)
+	s := NewSession(context.Background(), db, dialect.Sqlite3, false)
 
 	mapslice, err := s.Select(authorIdColumn, authorNameColumn).From(authorTable).Build().FetchMap()
 	asserts.Nil(err)
@@ -50,27 +36,12 @@ func TestFetchMap(t *testing.T) {
 }
 
 func TestFetchInto(t *testing.T) {
-	authorTable := model.NewTable("author")
-
-	authorIdColumn := model.NewInt64Column(authorTable, "id")
-	authorNameColumn := model.NewTextColumn(authorTable, "name")
-
-	db, err := sql.Open(dialect.MySQL, "user:password@tcp(127.0.0.1:3306)/sqlike")
-	if err != nil {
-		panic(err)
-	}
-	defer db.Close()
-
 	asserts := assert.New(t)
 
-	if _, err := db.Exec("TRUNCATE `author`"); err != nil {
-		panic(err)
-	}
-	if _, err := db.Exec("INSERT INTO `author` (`name`) VALUES ('William Shakespeare'), ('J. K. Rowling')"); err != nil {
-		panic(err)
-	}
+	db := openTestDB(t)
+	defer db.Close()
 
-	s := NewSession(context.Background(), db, dialect.MySQL, false)
+	s := NewSession(context.Background(), db, dialect.Sqlite3, false)
 
 	t.Run("PtrValue", func(t *testing.T) {
 		authors := make([]Author, 0)
@@ -105,28 +76,202 @@ func TestFetchInto(t *testing.T) {
 
 }
 
-func TestFetchOneInto(t *testing.T) {
-	authorTable := model.NewTable("author")
-
-	authorIdColumn := model.NewInt64Column(authorTable, "id")
-	authorNameColumn := model.NewTextColumn(authorTable, "name")
+// scannedAuthor is same as the row struct generated by sqlikegen, whose field names differ from the columns
+// to make sure that the fields are not mapped by reflection
+type scannedAuthor struct {
+	AuthorId   int64
+	AuthorName string
+}
 
-	db, err := sql.Open(dialect.MySQL, "user:password@tcp(127.0.0.1:3306)/sqlike")
-	if err != nil {
-		panic(err)
+func (r *scannedAuthor) SQLikeScanTargets(columns []string) []interface{} {
+	targets := make([]interface{}, len(columns))
+	for i, column := range columns {
+		switch column {
+		case "id":
+			targets[i] = &r.AuthorId
+		case "name":
+			targets[i] = &r.AuthorName
+		}
 	}
-	defer db.Close()
+	return targets
+}
 
+func TestFetchIntoScanTargets(t *testing.T) {
 	asserts := assert.New(t)
 
-	if _, err := db.Exec("TRUNCATE `author`"); err != nil {
-		panic(err)
-	}
-	if _, err := db.Exec("INSERT INTO `author` (`name`) VALUES ('William Shakespeare'), ('J. K. Rowling')"); err != nil {
-		panic(err)
+	db := openTestDB(t)
+	defer db.Close()
+
+	s := NewSession(context.Background(), db, dialect.Sqlite3, false)
+
+	authors := make([]scannedAuthor, 0)
+	err := s.SelectFrom(authorTable).OrderBy(authorIdColumn.Asc()).Build().FetchInto(&authors)
+	asserts.Nil(err)
+	asserts.Equal([]scannedAuthor{{AuthorId: 1, AuthorName: "William Shakespeare"}, {AuthorId: 2, AuthorName: "J. K. Rowling"}}, authors)
+
+	author := scannedAuthor{}
+	ok, err := s.Select(authorNameColumn, authorIdColumn.Sum().As("total")).From(authorTable).Where(authorIdColumn.Eq(2)).Build().FetchOneInto(&author)
+	asserts.Nil(err)
+	asserts.True(ok)
+	asserts.Equal(scannedAuthor{AuthorName: "J. K. Rowling"}, author)
+}
+
+type baseEntity struct {
+	Id int64 `sqlike:"id"`
+}
+
+type entityBook struct {
+	baseEntity
+	Title    string `sqlike:"title"`
+	AuthorId int64  `sqlike:"author_id"`
+}
+
+type BaseEntity struct {
+	Id int64 `sqlike:"id"`
+}
+
+type ptrEntityBook struct {
+	*BaseEntity
+	Title    string `sqlike:"title"`
+	AuthorId int64  `sqlike:"author_id"`
+}
+
+func TestFetchIntoNestedStruct(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	s := NewSession(context.Background(), db, dialect.Sqlite3, false)
+
+	result :=
+		s.InsertInto(bookTable).
+			Record(&model.Record{Value: &entityBook{Title: "Hamlet", AuthorId: 1}, Skip: []model.Column{bookIdColumn}}).
+			Build().
+			Execute()
+	if err := result.Error(); err != nil {
+		t.Fatal(err)
 	}
 
-	s := NewSession(context.Background(), db, dialect.MySQL, false)
+	t.Run("Embedded", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		books := make([]entityBook, 0)
+		err := s.SelectFrom(bookTable).Build().FetchInto(&books)
+		asserts.Nil(err)
+		asserts.Equal([]entityBook{{baseEntity: baseEntity{Id: 1}, Title: "Hamlet", AuthorId: 1}}, books)
+	})
+
+	t.Run("FlatJoin", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		// 同名のidは後のauthor.idの値になる
+		type BookAndAuthor struct {
+			Id       int64  `sqlike:"id"`
+			Title    string `sqlike:"title"`
+			AuthorId int64  `sqlike:"author_id"`
+			Name     string `sqlike:"name"`
+		}
+		rows := make([]BookAndAuthor, 0)
+		err :=
+			s.SelectFrom(bookTable).
+				InnerJoin(authorTable, bookAuthorIdColumn.EqCol(authorIdColumn)).
+				Build().
+				FetchInto(&rows)
+		asserts.Nil(err)
+		asserts.Equal([]BookAndAuthor{{Id: 1, Title: "Hamlet", AuthorId: 1, Name: "William Shakespeare"}}, rows)
+	})
+
+	t.Run("Ambiguous", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		// 入れ子のAuthor.Idのカラム名はauthor_idとなり、Book.AuthorIdと区別できない
+		type BookWithAuthor struct {
+			Book
+			Author Author
+		}
+		rows := make([]BookWithAuthor, 0)
+		err :=
+			s.Select(bookIdColumn, bookTitleColumn, bookAuthorIdColumn, authorIdColumn, authorNameColumn).
+				From(bookTable).
+				InnerJoin(authorTable, bookAuthorIdColumn.EqCol(authorIdColumn)).
+				Build().
+				FetchInto(&rows)
+		asserts.True(errors.Is(err, statement.ErrorAmbiguousColumn))
+	})
+
+	t.Run("Prefix", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		type BookWithWriter struct {
+			Book
+			Writer Author `sqlike:",prefix=writer_"`
+		}
+		// Asはカラムを書き換えるため、別のカラムを使う
+		writerId := model.NewInt64Column(authorTable, "id").As("writer_id")
+		writerName := model.NewTextColumn(authorTable, "name").As("writer_name")
+
+		row := BookWithWriter{}
+		ok, err :=
+			s.Select(bookIdColumn, bookTitleColumn, writerId, writerName).
+				From(bookTable).
+				InnerJoin(authorTable, bookAuthorIdColumn.EqCol(authorIdColumn)).
+				Build().
+				FetchOneInto(&row)
+		asserts.Nil(err)
+		asserts.True(ok)
+		asserts.Equal(BookWithWriter{
+			Book:   Book{Id: 1, Title: "Hamlet"},
+			Writer: Author{Id: 1, Name: "William Shakespeare"},
+		}, row)
+	})
+
+	t.Run("DefaultPrefix", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		type BookWithWriter struct {
+			Book
+			Writer Author
+		}
+		writerId := model.NewInt64Column(authorTable, "id").As("writer_id")
+		writerName := model.NewTextColumn(authorTable, "name").As("writer_name")
+
+		row := BookWithWriter{}
+		ok, err :=
+			s.Select(bookIdColumn, bookTitleColumn, writerId, writerName).
+				From(bookTable).
+				InnerJoin(authorTable, bookAuthorIdColumn.EqCol(authorIdColumn)).
+				Build().
+				FetchOneInto(&row)
+		asserts.Nil(err)
+		asserts.True(ok)
+		asserts.Equal(BookWithWriter{
+			Book:   Book{Id: 1, Title: "Hamlet"},
+			Writer: Author{Id: 1, Name: "William Shakespeare"},
+		}, row)
+	})
+
+	t.Run("PointerEmbedded", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		// nilの埋め込みのカラムは挿入しない
+		asserts.Nil(
+			s.InsertInto(bookTable).
+				Record(&model.Record{Value: &ptrEntityBook{Title: "Macbeth", AuthorId: 1}}).
+				Build().Execute().Error())
+
+		books := make([]ptrEntityBook, 0)
+		err := s.SelectFrom(bookTable).Where(bookTitleColumn.Eq("Macbeth")).Build().FetchInto(&books)
+		asserts.Nil(err)
+		asserts.Equal([]ptrEntityBook{{BaseEntity: &BaseEntity{Id: 2}, Title: "Macbeth", AuthorId: 1}}, books)
+	})
+}
+
+func TestFetchOneInto(t *testing.T) {
+	asserts := assert.New(t)
+
+	db := openTestDB(t)
+	defer db.Close()
+
+	s := NewSession(context.Background(), db, dialect.Sqlite3, false)
 
 	t.Run("Found", func(t *testing.T) {
 		author := Author{}
@@ -155,3 +300,496 @@ func TestFetchOneInto(t *testing.T) {
 	})
 
 }
+
+func TestIterate(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	s := NewSession(context.Background(), db, dialect.Sqlite3, false)
+
+	t.Run("All", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		var author Author
+		names := make([]string, 0)
+		err := s.SelectFrom(authorTable).OrderBy(authorIdColumn.Asc()).Build().Iterate(&author, func() error {
+			names = append(names, author.Name)
+			return nil
+		})
+		asserts.Nil(err)
+		asserts.Equal([]string{"William Shakespeare", "J. K. Rowling"}, names)
+	})
+
+	t.Run("StopIteration", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		var author Author
+		count := 0
+		err := s.SelectFrom(authorTable).Build().Iterate(&author, func() error {
+			count++
+			return statement.ErrorStopIteration
+		})
+		asserts.Nil(err)
+		asserts.Equal(1, count)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		errFailed := errors.New("failed")
+
+		asserts := assert.New(t)
+
+		var author Author
+		err := s.SelectFrom(authorTable).Build().Iterate(&author, func() error {
+			return errFailed
+		})
+		asserts.Equal(errFailed, err)
+
+		// Connection must be released
+		rows, err := s.SelectFrom(authorTable).Build().FetchMap()
+		asserts.Nil(err)
+		asserts.Len(rows, 2)
+	})
+
+	t.Run("NotStructPtr", func(t *testing.T) {
+		var id int
+		err := s.SelectFrom(authorTable).Build().Iterate(&id, func() error { return nil })
+		assert.Equal(t, statement.ErrorMustBeAStructPtr, err)
+	})
+}
+
+func TestCursor(t *testing.T) {
+	asserts := assert.New(t)
+
+	db := openTestDB(t)
+	defer db.Close()
+
+	s := NewSession(context.Background(), db, dialect.Sqlite3, false)
+
+	var author Author
+	cur, err := s.SelectFrom(authorTable).Where(authorIdColumn.Eq(2)).Build().Cursor(&author)
+	asserts.Nil(err)
+
+	asserts.True(cur.Next())
+	asserts.Nil(cur.Scan())
+	asserts.Equal(2, author.Id)
+	asserts.Equal("J. K. Rowling", author.Name)
+	asserts.False(cur.Next())
+	asserts.Nil(cur.Err())
+
+	asserts.Nil(cur.Close())
+	asserts.Nil(cur.Close())
+}
+
+func TestSeekAfter(t *testing.T) {
+	asserts := assert.New(t)
+
+	db := openTestDB(t)
+	defer db.Close()
+
+	s := NewSession(context.Background(), db, dialect.Sqlite3, false)
+
+	asserts.Nil(
+		s.InsertInto(authorTable).
+			Columns(authorNameColumn).
+			Values("Jane Austen").
+			Values("Mark Twain").
+			Values("Charles Dickens").
+			Build().Execute().Error())
+
+	orders := []*model.SortOrder{authorNameColumn.Asc(), authorIdColumn.Asc()}
+
+	names := make([]string, 0)
+	token := ""
+	for {
+		authors := make([]Author, 0)
+
+		var err error
+		if token == "" {
+			err = s.SelectFrom(authorTable).OrderBy(orders...).LimitAndOffset(2, 0).Build().FetchInto(&authors)
+		} else {
+			var (
+				lastName string
+				lastId   int64
+			)
+			asserts.Nil(statement.DecodePageToken(token, &lastName, &lastId))
+			err = s.SelectFrom(authorTable).OrderBy(orders...).SeekAfter(lastName, lastId).LimitAndOffset(2, 0).Build().FetchInto(&authors)
+		}
+		asserts.Nil(err)
+
+		if len(authors) == 0 {
+			break
+		}
+		for _, author := range authors {
+			names = append(names, author.Name)
+		}
+
+		last := authors[len(authors)-1]
+		token, err = statement.EncodePageToken(last.Name, last.Id)
+		asserts.Nil(err)
+	}
+
+	asserts.Equal([]string{"Charles Dickens", "J. K. Rowling", "Jane Austen", "Mark Twain", "William Shakespeare"}, names)
+}
+
+func TestSubQuery(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	s := NewSession(context.Background(), db, dialect.Sqlite3, false)
+
+	if err := s.InsertInto(bookTable).
+		Columns(bookTitleColumn, bookAuthorIdColumn).
+		Values("Hamlet", 1).
+		Values("Macbeth", 1).
+		Values("Harry Potter", 2).
+		Build().Execute().Error(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("In", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		books := make([]Book, 0)
+		err := s.SelectFrom(bookTable).
+			Where(bookAuthorIdColumn.InSubQuery(
+				s.Select(authorIdColumn).From(authorTable).Where(authorNameColumn.Eq("William Shakespeare")).Build())).
+			OrderBy(bookIdColumn.Asc()).
+			Build().
+			FetchInto(&books)
+		asserts.Nil(err)
+		asserts.Len(books, 2)
+		asserts.Equal("Hamlet", books[0].Title)
+		asserts.Equal("Macbeth", books[1].Title)
+	})
+
+	t.Run("NotExists", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		asserts.Nil(s.InsertInto(authorTable).Columns(authorNameColumn).Values("Jane Austen").Build().Execute().Error())
+
+		authors := make([]Author, 0)
+		err := s.SelectFrom(authorTable).
+			Where(model.NotExists(
+				s.SelectFrom(bookTable).Where(bookAuthorIdColumn.EqCol(authorIdColumn)).Build())).
+			Build().
+			FetchInto(&authors)
+		asserts.Nil(err)
+		asserts.Len(authors, 1)
+		asserts.Equal("Jane Austen", authors[0].Name)
+	})
+
+	t.Run("DerivedTable", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		counts := model.NewDerivedTable(
+			s.Select(bookAuthorIdColumn, model.Count(bookIdColumn).As("cnt")).
+				From(bookTable).
+				Where(bookTitleColumn.NotEq("Macbeth")).
+				GroupBy(bookAuthorIdColumn).
+				Build(),
+			"counts")
+		countsAuthorIdColumn := model.NewInt64Column(counts, "author_id")
+		countsCntColumn := model.NewInt64Column(counts, "cnt")
+
+		rows, err := s.Select(authorNameColumn, countsCntColumn).
+			From(authorTable).
+			InnerJoin(counts, countsAuthorIdColumn.EqCol(authorIdColumn)).
+			OrderBy(authorIdColumn.Asc()).
+			Build().
+			FetchMap()
+		asserts.Nil(err)
+		asserts.Len(rows, 2)
+		asserts.Equal("William Shakespeare", rows[0]["name"])
+		asserts.Equal("1", rows[0]["cnt"])
+		asserts.Equal("J. K. Rowling", rows[1]["name"])
+		asserts.Equal("1", rows[1]["cnt"])
+	})
+}
+
+func TestUnion(t *testing.T) {
+	asserts := assert.New(t)
+
+	db := openTestDB(t)
+	defer db.Close()
+
+	s := NewSession(context.Background(), db, dialect.Sqlite3, false)
+
+	if err := s.InsertInto(bookTable).
+		Columns(bookTitleColumn, bookAuthorIdColumn).
+		Values("Hamlet", 1).
+		Values("Harry Potter", 2).
+		Build().Execute().Error(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Asはカラムを書き換えるため、別のカラムを使う
+	titleAsName := model.NewTextColumn(bookTable, "title").As("name")
+
+	rows, err := s.Select(authorNameColumn).From(authorTable).
+		Where(authorIdColumn.Eq(1)).
+		UnionAll(s.Select(titleAsName).From(bookTable).Where(bookAuthorIdColumn.Eq(2)).Build()).
+		Union(s.Select(titleAsName).From(bookTable).Build()).
+		OrderBy(authorNameColumn.Asc()).
+		Build().
+		FetchMap()
+	asserts.Nil(err)
+	asserts.Len(rows, 3)
+	asserts.Equal("Hamlet", rows[0]["name"])
+	asserts.Equal("Harry Potter", rows[1]["name"])
+	asserts.Equal("William Shakespeare", rows[2]["name"])
+}
+
+func TestHaving(t *testing.T) {
+	asserts := assert.New(t)
+
+	db := openTestDB(t)
+	defer db.Close()
+
+	s := NewSession(context.Background(), db, dialect.Sqlite3, false)
+
+	if err := s.InsertInto(bookTable).
+		Columns(bookTitleColumn, bookAuthorIdColumn).
+		Values("Hamlet", 1).
+		Values("Macbeth", 1).
+		Values("Harry Potter", 2).
+		Build().Execute().Error(); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := s.Select(bookAuthorIdColumn, model.Count(bookIdColumn).As("cnt")).
+		From(bookTable).
+		GroupBy(bookAuthorIdColumn).
+		Having(model.Count(bookIdColumn).GtOrEq(2)).
+		Build().
+		FetchMap()
+	asserts.Nil(err)
+	asserts.Len(rows, 1)
+	asserts.Equal("1", rows[0]["author_id"])
+	asserts.Equal("2", rows[0]["cnt"])
+}
+
+func TestAggregate(t *testing.T) {
+	asserts := assert.New(t)
+
+	db := openTestDB(t)
+	defer db.Close()
+
+	s := NewSession(context.Background(), db, dialect.Sqlite3, false)
+
+	if err := s.InsertInto(bookTable).
+		Columns(bookTitleColumn, bookAuthorIdColumn).
+		Values("Hamlet", 1).
+		Values("Macbeth", 1).
+		Values("Harry Potter", 2).
+		Build().Execute().Error(); err != nil {
+		t.Fatal(err)
+	}
+
+	type Summary struct {
+		AuthorId int64   `sqlike:"author_id"`
+		Books    int64   `sqlike:"books"`
+		MaxId    int64   `sqlike:"max_id"`
+		AvgId    float64 `sqlike:"avg_id"`
+		Titles   string  `sqlike:"titles"`
+	}
+
+	summaries := make([]Summary, 0)
+	err := s.Select(
+		bookAuthorIdColumn,
+		model.CountAll().As("books"),
+		bookIdColumn.Max().As("max_id"),
+		bookIdColumn.Avg().As("avg_id"),
+		model.GroupConcat(bookTitleColumn).Separator("/").As("titles")).
+		From(bookTable).
+		GroupBy(bookAuthorIdColumn).
+		Having(bookIdColumn.Max().Gt(0)).
+		OrderBy(bookIdColumn.Max().Desc()).
+		Build().
+		FetchInto(&summaries)
+	asserts.Nil(err)
+	if asserts.Len(summaries, 2) {
+		asserts.Equal(Summary{AuthorId: 2, Books: 1, MaxId: 3, AvgId: 3, Titles: "Harry Potter"}, summaries[0])
+		asserts.Equal(Summary{AuthorId: 1, Books: 2, MaxId: 2, AvgId: 1.5, Titles: "Hamlet/Macbeth"}, summaries[1])
+	}
+}
+
+func TestWindow(t *testing.T) {
+	asserts := assert.New(t)
+
+	db := openTestDB(t)
+	defer db.Close()
+
+	s := NewSession(context.Background(), db, dialect.Sqlite3, false)
+
+	if err := s.InsertInto(bookTable).
+		Columns(bookTitleColumn, bookAuthorIdColumn).
+		Values("Hamlet", 1).
+		Values("Macbeth", 1).
+		Values("Harry Potter", 2).
+		Build().Execute().Error(); err != nil {
+		t.Fatal(err)
+	}
+
+	type Ranked struct {
+		Title    string         `sqlike:"title"`
+		Rank     int64          `sqlike:"rnk"`
+		Previous sql.NullString `sqlike:"previous"`
+		Total    int64          `sqlike:"total"`
+	}
+
+	rnk := model.RowNumber().Over(model.PartitionBy(bookAuthorIdColumn), model.OrderBy(bookIdColumn.Desc()))
+	ranked := make([]Ranked, 0)
+	err := s.Select(
+		bookTitleColumn,
+		rnk.As("rnk"),
+		model.Lag(bookTitleColumn, 1).Over(model.OrderBy(bookIdColumn.Asc())).As("previous"),
+		model.Aggregate(bookIdColumn.Sum()).Over(model.OrderBy(bookIdColumn.Asc()), model.RowsBetween(model.UnboundedPreceding, model.CurrentRow)).As("total")).
+		From(bookTable).
+		Where(bookIdColumn.Gt(1)).
+		OrderBy(rnk.Asc(), bookIdColumn.Asc()).
+		Build().
+		FetchInto(&ranked)
+	asserts.Nil(err)
+	if asserts.Len(ranked, 2) {
+		asserts.Equal(Ranked{Title: "Macbeth", Rank: 1, Total: 2}, ranked[0])
+		asserts.Equal(Ranked{Title: "Harry Potter", Rank: 1, Previous: sql.NullString{String: "Macbeth", Valid: true}, Total: 5}, ranked[1])
+	}
+}
+
+func TestWith(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	s := NewSession(context.Background(), db, dialect.Sqlite3, false)
+
+	if _, err := db.Exec(`INSERT INTO category (id, name, parent_id)
+VALUES (1, 'Books', NULL), (2, 'Novels', 1), (3, 'Mystery', 2), (4, 'Comics', 1), (5, 'Music', NULL)`); err != nil {
+		t.Fatal(err)
+	}
+
+	tree := model.NewTable("tree")
+	treeIdColumn := model.NewInt64Column(tree, "id")
+
+	// descendants of the category
+	descendants := func(id int64) statement.WithBranchStep {
+		return s.WithRecursive("tree",
+			s.Select(categoryIdColumn).From(categoryTable).
+				Where(categoryIdColumn.Eq(id)).
+				UnionAll(
+					s.Select(categoryIdColumn).From(categoryTable).
+						InnerJoin(tree, categoryParentIdColumn.EqCol(treeIdColumn)).
+						Build()).
+				Build())
+	}
+
+	type Category struct {
+		Name string `sqlike:"name"`
+	}
+
+	t.Run("Select", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		with := descendants(1)
+		categories := make([]Category, 0)
+		err := with.Select(categoryNameColumn).From(categoryTable).
+			InnerJoin(with.Table(), categoryIdColumn.EqCol(treeIdColumn)).
+			Where(categoryIdColumn.NotEq(1)).
+			OrderBy(categoryIdColumn.Asc()).
+			Build().
+			FetchInto(&categories)
+		asserts.Nil(err)
+		asserts.Equal([]Category{{Name: "Novels"}, {Name: "Mystery"}, {Name: "Comics"}}, categories)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		err := descendants(2).
+			Update(categoryTable).
+			SetValue(categoryNameColumn.Value("Fiction")).
+			Where(categoryIdColumn.InSubQuery(s.Select(treeIdColumn).From(tree).Build()), categoryIdColumn.NotEq(2)).
+			Build().
+			Execute().
+			Error()
+		asserts.Nil(err)
+
+		categories := make([]Category, 0)
+		asserts.Nil(s.Select(categoryNameColumn).From(categoryTable).OrderBy(categoryIdColumn.Asc()).Build().FetchInto(&categories))
+		asserts.Equal([]Category{{Name: "Books"}, {Name: "Novels"}, {Name: "Fiction"}, {Name: "Comics"}, {Name: "Music"}}, categories)
+	})
+
+	t.Run("DeleteFrom", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		with := descendants(1)
+		err := with.
+			DeleteFrom(categoryTable).
+			Where(categoryIdColumn.InSubQuery(s.Select(treeIdColumn).From(with.Table()).Build())).
+			Build().
+			Execute().
+			Error()
+		asserts.Nil(err)
+
+		categories := make([]Category, 0)
+		asserts.Nil(s.SelectFrom(categoryTable).Build().FetchInto(&categories))
+		asserts.Equal([]Category{{Name: "Music"}}, categories)
+	})
+}
+
+func TestExpression(t *testing.T) {
+	asserts := assert.New(t)
+
+	db := openTestDB(t)
+	defer db.Close()
+
+	s := NewSession(context.Background(), db, dialect.Sqlite3, false)
+
+	if err := s.InsertInto(bookTable).
+		Columns(bookTitleColumn, bookAuthorIdColumn).
+		Values("Hamlet", 1).
+		Values("Macbeth", 1).
+		Values("Harry Potter", 2).
+		Build().Execute().Error(); err != nil {
+		t.Fatal(err)
+	}
+
+	title := model.NewTextColumn(bookTable, "title")
+	asserts.Nil(
+		s.Update(bookTable).
+			SetValue(model.ExprValue(title, model.Upper(title))).
+			Where(model.Lower(title).Like("%h%")).
+			Build().
+			Execute().
+			Error())
+
+	type Labeled struct {
+		Label string `sqlike:"label"`
+		Size  string `sqlike:"size"`
+	}
+
+	labeled := make([]Labeled, 0)
+	err := s.Select(
+		model.Concat(bookTitleColumn, " #", bookIdColumn).As("label"),
+		model.CaseWhen(bookIdColumn.Gt(2), "large").Else("small").End().As("size")).
+		From(bookTable).
+		Where(model.Coalesce(bookAuthorIdColumn, 0).Eq(1).Or(bookIdColumn.Eq(3))).
+		OrderBy(model.CaseWhen(bookIdColumn.Eq(2), 0).Else(1).End().Asc(), bookIdColumn.Asc()).
+		Build().
+		FetchInto(&labeled)
+	asserts.Nil(err)
+	asserts.Equal([]Labeled{
+		{Label: "MACBETH #2", Size: "small"},
+		{Label: "HAMLET #1", Size: "small"},
+		{Label: "HARRY POTTER #3", Size: "large"},
+	}, labeled)
+
+	// dialectに依存する式も条件句ではdialectに合わせて出力する
+	titles := make([]Labeled, 0)
+	err = s.Select(title.As("label")).
+		From(bookTable).
+		Where(model.Concat(bookTitleColumn, "!").Eq("HAMLET!")).
+		Build().
+		FetchInto(&titles)
+	asserts.Nil(err)
+	asserts.Equal([]Labeled{{Label: "HAMLET"}}, titles)
+}
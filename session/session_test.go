@@ -0,0 +1,564 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/tmarcus87/sqlike/dialect"
+	"github.com/tmarcus87/sqlike/model"
+	"github.com/tmarcus87/sqlike/statement"
+	"testing"
+)
+
+const testSchema = `
+CREATE TABLE author
+(
+    id   INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(300)
+);
+
+CREATE TABLE book
+(
+    id        INTEGER PRIMARY KEY AUTOINCREMENT,
+    title     VARCHAR(300),
+    author_id BIGINT NOT NULL
+);
+
+CREATE TABLE category
+(
+    id        INTEGER PRIMARY KEY AUTOINCREMENT,
+    name      VARCHAR(300),
+    parent_id BIGINT
+);
+`
+
+var (
+	authorTable      = model.NewTable("author")
+	authorIdColumn   = model.NewInt64Column(authorTable, "id")
+	authorNameColumn = model.NewTextColumn(authorTable, "name")
+
+	bookTable          = model.NewTable("book")
+	bookIdColumn       = model.NewInt64Column(bookTable, "id")
+	bookTitleColumn    = model.NewTextColumn(bookTable, "title")
+	bookAuthorIdColumn = model.NewInt64Column(bookTable, "author_id")
+
+	categoryTable          = model.NewTable("category")
+	categoryIdColumn       = model.NewInt64Column(categoryTable, "id")
+	categoryNameColumn     = model.NewTextColumn(categoryTable, "name")
+	categoryParentIdColumn = model.NewInt64Column(categoryTable, "parent_id")
+)
+
+type Book struct {
+	Id       int64  `sqlike:"id"`
+	Title    string `sqlike:"title"`
+	AuthorId int64  `sqlike:"author_id"`
+}
+
+// openTestDB opens in-memory SQLite database which has the test schema
+func openTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open(dialect.Sqlite3, ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// in-memoryのDBはコネクション毎に作られるため、コネクションを1つに制限する
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(testSchema); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("INSERT INTO author (name) VALUES ('William Shakespeare'), ('J. K. Rowling')"); err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+func TestSelectOne(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	s := NewSession(context.Background(), db, dialect.Sqlite3, false)
+
+	rows, err := s.SelectOne().Build().FetchMap()
+
+	asserts := assert.New(t)
+	asserts.Nil(err)
+	asserts.Len(rows, 1)
+}
+
+func TestInsertInto(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	s := NewSession(context.Background(), db, dialect.Sqlite3, false)
+
+	t.Run("Values", func(t *testing.T) {
+		result :=
+			s.InsertInto(bookTable).
+				Columns(bookTitleColumn, bookAuthorIdColumn).
+				Values("Hamlet", 1).
+				Values("King Lear", 1).
+				Build().
+				Execute()
+
+		asserts := assert.New(t)
+		asserts.Nil(result.Error())
+		affected, err := result.AffectedRows()
+		asserts.Nil(err)
+		asserts.Equal(int64(2), affected)
+		lastInsertId, err := result.LastInsertId()
+		asserts.Nil(err)
+		asserts.Equal(int64(2), lastInsertId)
+	})
+
+	t.Run("Record", func(t *testing.T) {
+		result :=
+			s.InsertInto(bookTable).
+				Record(
+					&model.Record{Value: &Book{Title: "Harry Potter", AuthorId: 2}, Skip: []model.Column{bookIdColumn}}).
+				Build().
+				Execute()
+
+		asserts := assert.New(t)
+		asserts.Nil(result.Error())
+
+		book := Book{}
+		ok, err := s.SelectFrom(bookTable).Where(bookTitleColumn.Eq("Harry Potter")).Build().FetchOneInto(&book)
+		asserts.Nil(err)
+		asserts.True(ok)
+		asserts.Equal(int64(2), book.AuthorId)
+	})
+
+	t.Run("OnDuplicateKeyIgnore", func(t *testing.T) {
+		result :=
+			s.InsertInto(bookTable).
+				Columns(bookIdColumn, bookTitleColumn, bookAuthorIdColumn).
+				Values(1, "Macbeth", 1).
+				OnDuplicateKeyIgnore().
+				Build().
+				Execute()
+
+		asserts := assert.New(t)
+		asserts.Nil(result.Error())
+		affected, err := result.AffectedRows()
+		asserts.Nil(err)
+		asserts.Equal(int64(0), affected)
+	})
+
+	t.Run("OnDuplicateKeyUpdate", func(t *testing.T) {
+		result :=
+			s.InsertInto(bookTable).
+				Columns(bookIdColumn, bookTitleColumn, bookAuthorIdColumn).
+				Values(1, "Macbeth", 1).
+				OnDuplicateKeyUpdate().
+				SetValue(bookTitleColumn.Value("Macbeth")).
+				Build().
+				Execute()
+
+		asserts := assert.New(t)
+		asserts.Nil(result.Error())
+
+		book := Book{}
+		ok, err := s.SelectFrom(bookTable).Where(bookIdColumn.Eq(1)).Build().FetchOneInto(&book)
+		asserts.Nil(err)
+		asserts.True(ok)
+		asserts.Equal("Macbeth", book.Title)
+	})
+
+	t.Run("OnDuplicateKeyUpdateSetExpr", func(t *testing.T) {
+		result :=
+			s.InsertInto(bookTable).
+				Columns(bookIdColumn, bookTitleColumn, bookAuthorIdColumn).
+				Values(1, " (2nd edition)", 2).
+				OnDuplicateKeyUpdate().
+				SetExpr(bookTitleColumn, model.Concat(bookTitleColumn, model.InsertedValue(bookTitleColumn))).
+				SetExpr(bookAuthorIdColumn, model.ColumnOf(bookAuthorIdColumn).Plus(model.InsertedValue(bookAuthorIdColumn))).
+				Build().
+				Execute()
+
+		asserts := assert.New(t)
+		asserts.Nil(result.Error())
+
+		book := Book{}
+		ok, err := s.SelectFrom(bookTable).Where(bookIdColumn.Eq(1)).Build().FetchOneInto(&book)
+		asserts.Nil(err)
+		asserts.True(ok)
+		asserts.Equal("Macbeth (2nd edition)", book.Title)
+		asserts.Equal(int64(3), book.AuthorId)
+	})
+}
+
+func TestUpsert(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	s := NewSession(context.Background(), db, dialect.Sqlite3, false)
+
+	asserts := assert.New(t)
+	asserts.Nil(s.InsertInto(bookTable).Record(&model.Record{Value: &Book{Id: 1, Title: "Hamlet", AuthorId: 1}}).Build().Execute().Error())
+
+	result :=
+		s.Upsert(bookTable).
+			Records(
+				&model.Record{Value: &Book{Id: 1, Title: "Macbeth", AuthorId: 2}},
+				&model.Record{Value: &Book{Id: 2, Title: "Harry Potter", AuthorId: 2}}).
+			OnConflict(bookIdColumn).
+			UpdateAllExcept(bookAuthorIdColumn).
+			Build().
+			Execute()
+	asserts.Nil(result.Error())
+
+	books := make([]Book, 0)
+	asserts.Nil(s.SelectFrom(bookTable).OrderBy(bookIdColumn.Asc()).Build().FetchInto(&books))
+	asserts.Equal([]Book{{Id: 1, Title: "Macbeth", AuthorId: 1}, {Id: 2, Title: "Harry Potter", AuthorId: 2}}, books)
+}
+
+func TestInsertIntoChunked(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	s := NewSession(context.Background(), db, dialect.Sqlite3, false)
+
+	books := func(ids ...int64) []*model.Record {
+		records := make([]*model.Record, 0)
+		for _, id := range ids {
+			records = append(records, &model.Record{Value: &Book{Id: id, Title: "Hamlet", AuthorId: 1}})
+		}
+		return records
+	}
+
+	t.Run("InTx", func(t *testing.T) {
+		result :=
+			s.InsertInto(bookTable).
+				Record(books(1, 2, 3, 4, 5)...).
+				Chunked(2).
+				InTx().
+				Build().
+				Execute()
+
+		asserts := assert.New(t)
+		asserts.Nil(result.Error())
+		affected, err := result.AffectedRows()
+		asserts.Nil(err)
+		asserts.Equal(int64(5), affected)
+		lastInsertId, err := result.LastInsertId()
+		asserts.Nil(err)
+		asserts.Equal(int64(2), lastInsertId)
+		_, err = result.SkippedRows()
+		asserts.True(errors.Is(err, dialect.ErrorNotSupported))
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		// 2つ目のチャンクが重複するため、1つ目のチャンクもロールバックされる
+		result :=
+			s.InsertInto(bookTable).
+				Record(books(6, 7, 1)...).
+				Chunked(2).
+				InTx().
+				Build().
+				Execute()
+
+		asserts := assert.New(t)
+		asserts.NotNil(result.Error())
+
+		rows, err := s.SelectFrom(bookTable).Build().FetchMap()
+		asserts.Nil(err)
+		asserts.Len(rows, 5)
+	})
+
+	t.Run("TxSession", func(t *testing.T) {
+		tx, err := s.Begin()
+		asserts := assert.New(t)
+		asserts.Nil(err)
+		defer tx.Close()
+
+		result :=
+			tx.InsertInto(bookTable).
+				Record(books(6, 7, 8)...).
+				Chunked(2).
+				InTx().
+				Build().
+				Execute()
+		asserts.Nil(result.Error())
+		asserts.Nil(tx.Rollback())
+
+		rows, err := s.SelectFrom(bookTable).Build().FetchMap()
+		asserts.Nil(err)
+		asserts.Len(rows, 5)
+	})
+}
+
+func TestReturning(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	s := NewSession(context.Background(), db, dialect.Sqlite3, false)
+
+	t.Run("InsertInto", func(t *testing.T) {
+		books := make([]Book, 0)
+		err :=
+			s.InsertInto(bookTable).
+				Columns(bookTitleColumn, bookAuthorIdColumn).
+				Values("Hamlet", 1).
+				Values("Harry Potter", 2).
+				Returning(bookIdColumn, bookTitleColumn, bookAuthorIdColumn).
+				Build().
+				FetchInto(&books)
+
+		asserts := assert.New(t)
+		asserts.Nil(err)
+		asserts.Equal([]Book{{Id: 1, Title: "Hamlet", AuthorId: 1}, {Id: 2, Title: "Harry Potter", AuthorId: 2}}, books)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		book := Book{}
+		ok, err :=
+			s.Update(bookTable).
+				SetValue(bookTitleColumn.Value("Macbeth")).
+				Where(bookIdColumn.Eq(1)).
+				Returning(model.NewAllColumnField()).
+				Build().
+				FetchOneInto(&book)
+
+		asserts := assert.New(t)
+		asserts.Nil(err)
+		asserts.True(ok)
+		asserts.Equal(Book{Id: 1, Title: "Macbeth", AuthorId: 1}, book)
+	})
+
+	t.Run("Execute", func(t *testing.T) {
+		result :=
+			s.DeleteFrom(bookTable).
+				Where(bookIdColumn.Gt(0)).
+				Returning(bookIdColumn).
+				Build().
+				Execute()
+
+		asserts := assert.New(t)
+		asserts.True(errors.Is(result.Error(), statement.ErrorReturningExecute))
+		_, err := result.AffectedRows()
+		asserts.True(errors.Is(err, statement.ErrorReturningExecute))
+
+		rows, err := s.SelectFrom(bookTable).Build().FetchMap()
+		asserts.Nil(err)
+		asserts.Len(rows, 2)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	s := NewSession(context.Background(), db, dialect.Sqlite3, false)
+
+	result :=
+		s.Update(authorTable).
+			SetValue(authorNameColumn.Value("Shakespeare")).
+			Where(authorIdColumn.Eq(1)).
+			Build().
+			Execute()
+
+	asserts := assert.New(t)
+	asserts.Nil(result.Error())
+	affected, err := result.AffectedRows()
+	asserts.Nil(err)
+	asserts.Equal(int64(1), affected)
+
+	author := Author{}
+	ok, err := s.SelectFrom(authorTable).Where(authorIdColumn.Eq(1)).Build().FetchOneInto(&author)
+	asserts.Nil(err)
+	asserts.True(ok)
+	asserts.Equal("Shakespeare", author.Name)
+}
+
+func TestUpdateSetExpr(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	s := NewSession(context.Background(), db, dialect.Sqlite3, false)
+
+	for i := 0; i < 3; i++ {
+		result :=
+			s.Update(authorTable).
+				SetExpr(authorIdColumn, model.ColumnOf(authorIdColumn).Plus(10)).
+				SetExpr(authorNameColumn, model.Upper(authorNameColumn)).
+				Where(authorNameColumn.Eq("William Shakespeare").Or(authorIdColumn.Gt(10))).
+				Build().
+				Execute()
+		if err := result.Error(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	asserts := assert.New(t)
+	author := Author{}
+	ok, err := s.SelectFrom(authorTable).Where(authorIdColumn.Eq(31)).Build().FetchOneInto(&author)
+	asserts.Nil(err)
+	asserts.True(ok)
+	asserts.Equal("WILLIAM SHAKESPEARE", author.Name)
+}
+
+func TestDeleteFrom(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	s := NewSession(context.Background(), db, dialect.Sqlite3, false)
+
+	result := s.DeleteFrom(authorTable).Where(authorIdColumn.Eq(1)).Build().Execute()
+
+	asserts := assert.New(t)
+	asserts.Nil(result.Error())
+	affected, err := result.AffectedRows()
+	asserts.Nil(err)
+	asserts.Equal(int64(1), affected)
+}
+
+func TestDeleteFromWithoutWhere(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	t.Run("Refused", func(t *testing.T) {
+		s := NewSession(context.Background(), db, dialect.Sqlite3, false)
+
+		asserts := assert.New(t)
+		err := s.DeleteFrom(authorTable).Build().Execute().Error()
+		asserts.True(errors.Is(err, statement.ErrorWriteWithoutWhere))
+		err = s.Update(authorTable).SetValue(authorNameColumn.Value("anonymous")).Build().Execute().Error()
+		asserts.True(errors.Is(err, statement.ErrorWriteWithoutWhere))
+
+		rows, err := s.SelectFrom(authorTable).Build().FetchMap()
+		asserts.Nil(err)
+		asserts.Len(rows, 2)
+	})
+
+	t.Run("Allowed", func(t *testing.T) {
+		s := NewSession(context.Background(), db, dialect.Sqlite3, false, AllowWriteWithoutWhere())
+
+		tx, err := s.Begin()
+		asserts := assert.New(t)
+		asserts.Nil(err)
+		defer tx.Close()
+
+		asserts.Nil(tx.Update(authorTable).SetValue(authorNameColumn.Value("anonymous")).Build().Execute().Error())
+		asserts.Nil(tx.Commit())
+
+		asserts.Nil(s.DeleteFrom(authorTable).Build().Execute().Error())
+		rows, err := s.SelectFrom(authorTable).Build().FetchMap()
+		asserts.Nil(err)
+		asserts.Empty(rows)
+	})
+}
+
+func TestTruncate(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	s := NewSession(context.Background(), db, dialect.Sqlite3, false)
+
+	asserts := assert.New(t)
+	asserts.Nil(s.Truncate(authorTable).Build().Execute().Error())
+
+	rows, err := s.SelectFrom(authorTable).Build().FetchMap()
+	asserts.Nil(err)
+	asserts.Empty(rows)
+}
+
+func TestTx(t *testing.T) {
+	db := openTestDB(t)
+	defer db.Close()
+
+	s := NewSession(context.Background(), db, dialect.Sqlite3, false)
+
+	t.Run("Commit", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		tx, err := s.Begin()
+		asserts.Nil(err)
+		asserts.Nil(tx.InsertInto(authorTable).Columns(authorNameColumn).Values("Ernest Hemingway").Build().Execute().Error())
+		asserts.Nil(tx.Commit())
+		asserts.True(tx.IsFlushed())
+		asserts.Nil(tx.Close())
+
+		rows, err := s.SelectFrom(authorTable).Build().FetchMap()
+		asserts.Nil(err)
+		asserts.Len(rows, 3)
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		tx, err := s.Begin()
+		asserts.Nil(err)
+		asserts.Nil(tx.DeleteFrom(authorTable).Where(authorIdColumn.Gt(0)).Build().Execute().Error())
+		asserts.Nil(tx.Close())
+
+		rows, err := s.SelectFrom(authorTable).Build().FetchMap()
+		asserts.Nil(err)
+		asserts.Len(rows, 3)
+	})
+
+	t.Run("Savepoint", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		tx, err := s.Begin()
+		asserts.Nil(err)
+		asserts.Equal(0, tx.Depth())
+		asserts.Nil(tx.Parent())
+
+		// Rollback to savepoint
+		sp, err := tx.Savepoint()
+		asserts.Nil(err)
+		asserts.Equal(1, sp.Depth())
+		asserts.Equal(tx, sp.Parent())
+		asserts.Nil(sp.InsertInto(authorTable).Columns(authorNameColumn).Values("Jane Austen").Build().Execute().Error())
+		asserts.Nil(sp.Close())
+		asserts.True(sp.IsFlushed())
+
+		rows, err := tx.SelectFrom(authorTable).Build().FetchMap()
+		asserts.Nil(err)
+		asserts.Len(rows, 3)
+
+		// Release savepoint
+		sp, err = tx.Savepoint()
+		asserts.Nil(err)
+		asserts.Nil(sp.InsertInto(authorTable).Columns(authorNameColumn).Values("Mark Twain").Build().Execute().Error())
+		asserts.Nil(sp.Commit())
+		asserts.False(tx.IsFlushed())
+
+		rows, err = tx.SelectFrom(authorTable).Build().FetchMap()
+		asserts.Nil(err)
+		asserts.Len(rows, 4)
+
+		asserts.Nil(tx.Rollback())
+
+		_, err = tx.Savepoint()
+		asserts.Equal(sql.ErrTxDone, err)
+	})
+
+	t.Run("Readonly", func(t *testing.T) {
+		_, err := NewSession(context.Background(), db, dialect.Sqlite3, true).Begin()
+		assert.Equal(t, ErrorReadonlySession, err)
+	})
+
+	t.Run("ReadonlyTx", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		tx, err := NewSession(context.Background(), db, dialect.Sqlite3, true).BeginTx(&sql.TxOptions{ReadOnly: true})
+		asserts.Nil(err)
+
+		rows, err := tx.SelectFrom(authorTable).Build().FetchMap()
+		asserts.Nil(err)
+		asserts.Len(rows, 3)
+		asserts.Nil(tx.Close())
+	})
+
+	t.Run("CanceledContext", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := NewSession(ctx, db, dialect.Sqlite3, false).BeginTx(&sql.TxOptions{Isolation: sql.LevelSerializable})
+		assert.Equal(t, context.Canceled, err)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"github.com/tmarcus87/sqlike/dialect"
 	"github.com/tmarcus87/sqlike/model"
 	"github.com/tmarcus87/sqlike/statement"
@@ -20,6 +21,17 @@ type TxSession interface {
 	Commit() error
 	Rollback() error
 	Close() error
+
+	// Savepoint creates SAVEPOINT and returns nested transaction session.
+	// Commit/Rollback/Close of the nested session are mapped to
+	// RELEASE SAVEPOINT/ROLLBACK TO SAVEPOINT.
+	Savepoint() (TxSession, error)
+
+	// Parent returns the outer transaction session, or nil if this is the outermost one.
+	Parent() TxSession
+
+	// Depth returns the nesting level of the transaction session (0 for the outermost one).
+	Depth() int
 }
 
 type basicTxSession struct {
@@ -27,15 +39,27 @@ type basicTxSession struct {
 	ctx     context.Context
 	dialect string
 
+	// ネストしたトランザクションの場合はSAVEPOINTで表現する
+	parent *basicTxSession
+	depth  int
+
+	allowWriteWithoutWhere bool
+
 	flushed bool
 }
 
 func (s *basicTxSession) rootStep() *statement.RootStep {
-	return statement.NewRootStep(
+	// 未登録のdialectの場合はStatementの組み立て時にエラーとなる
+	d, _ := dialect.Get(s.dialect)
+	root := statement.NewRootStep(
 		s.ctx,
-		dialect.GetDialectStatements(s.dialect),
+		d,
 		s.tx.QueryContext,
 		s.tx.ExecContext)
+	if s.allowWriteWithoutWhere {
+		root.AllowWriteWithoutWhere()
+	}
+	return root
 }
 
 func (s *basicTxSession) Explain() statement.ExplainSelectBranchStep {
@@ -62,6 +86,10 @@ func (s *basicTxSession) InsertInto(table model.Table) statement.InsertIntoBranc
 	return statement.NewInsertIntoBranchStep(s.rootStep(), table)
 }
 
+func (s *basicTxSession) Upsert(table model.Table) statement.UpsertBranchStep {
+	return statement.NewUpsertBranchStep(s.rootStep(), table)
+}
+
 func (s *basicTxSession) Update(table model.Table) statement.UpdateBranchStep {
 	return statement.NewUpdateBranchStep(s.rootStep(), table)
 }
@@ -74,8 +102,20 @@ func (s *basicTxSession) Truncate(table model.Table) statement.TruncateBranchSte
 	return statement.NewTruncateBranchStep(s.rootStep(), table)
 }
 
+func (s *basicTxSession) With(name string, query model.SubQuery, columns ...string) statement.WithBranchStep {
+	return statement.NewWithBranchStep(s.rootStep(), name, query, columns...)
+}
+
+func (s *basicTxSession) WithRecursive(name string, query model.SubQuery, columns ...string) statement.WithBranchStep {
+	return statement.NewWithRecursiveBranchStep(s.rootStep(), name, query, columns...)
+}
+
 func (s *basicTxSession) Commit() (err error) {
-	err = s.tx.Commit()
+	if s.depth > 0 {
+		err = s.exec("RELEASE SAVEPOINT " + s.savepointName())
+	} else {
+		err = s.tx.Commit()
+	}
 	if err == nil {
 		s.flushed = true
 	}
@@ -83,7 +123,14 @@ func (s *basicTxSession) Commit() (err error) {
 }
 
 func (s *basicTxSession) Rollback() (err error) {
-	err = s.tx.Rollback()
+	if s.depth > 0 {
+		// ROLLBACK TOではSAVEPOINTが残るため、続けてRELEASEする
+		if err = s.exec("ROLLBACK TO SAVEPOINT " + s.savepointName()); err == nil {
+			err = s.exec("RELEASE SAVEPOINT " + s.savepointName())
+		}
+	} else {
+		err = s.tx.Rollback()
+	}
 	if err == nil {
 		s.flushed = true
 	}
@@ -92,11 +139,51 @@ func (s *basicTxSession) Rollback() (err error) {
 
 func (s *basicTxSession) Close() error {
 	if !s.flushed {
-		return s.tx.Rollback()
+		return s.Rollback()
 	}
 	return nil
 }
 
+func (s *basicTxSession) Savepoint() (TxSession, error) {
+	if s.flushed {
+		return nil, sql.ErrTxDone
+	}
+
+	nested := &basicTxSession{
+		tx:      s.tx,
+		ctx:     s.ctx,
+		dialect: s.dialect,
+		parent:  s,
+		depth:   s.depth + 1,
+
+		allowWriteWithoutWhere: s.allowWriteWithoutWhere,
+	}
+	if err := s.exec("SAVEPOINT " + nested.savepointName()); err != nil {
+		return nil, err
+	}
+	return nested, nil
+}
+
+func (s *basicTxSession) Parent() TxSession {
+	if s.parent == nil {
+		return nil
+	}
+	return s.parent
+}
+
+func (s *basicTxSession) Depth() int {
+	return s.depth
+}
+
+func (s *basicTxSession) savepointName() string {
+	return fmt.Sprintf("sqlike_sp_%d", s.depth)
+}
+
+func (s *basicTxSession) exec(stmt string) error {
+	_, err := s.tx.ExecContext(s.ctx, stmt)
+	return err
+}
+
 func (s *basicTxSession) IsFlushed() bool {
 	return s.flushed
 }
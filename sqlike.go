@@ -3,6 +3,9 @@ package sqlike
 import (
 	"database/sql"
 	"fmt"
+	"github.com/tmarcus87/sqlike/dialect"
+	"github.com/tmarcus87/sqlike/session"
+	"net/url"
 	"strings"
 )
 
@@ -14,7 +17,7 @@ type ConnectionInfo struct {
 	Options  map[string]string `json:"options"  yaml:"options"`
 }
 
-func (c *ConnectionInfo) dataSourceName(database string, commonOptions map[string]string) string {
+func (c *ConnectionInfo) dataSourceName(driver, database string, commonOptions map[string]string) string {
 	merged := make(map[string]string)
 	for k, v := range commonOptions {
 		merged[k] = v
@@ -29,6 +32,24 @@ func (c *ConnectionInfo) dataSourceName(database string, commonOptions map[strin
 	}
 	opt := strings.Join(opts, "&")
 
+	switch strings.ToLower(driver) {
+	case dialect.Sqlite3:
+		// SQLiteはDatabaseをファイル名(もしくは':memory:')として扱う
+		if opt == "" {
+			return database
+		}
+		return fmt.Sprintf("%s?%s", database, opt)
+
+	case dialect.Postgres:
+		return fmt.Sprintf(
+			"postgres://%s@%s:%d/%s?%s",
+			url.UserPassword(c.Username, c.Password).String(),
+			c.Host,
+			c.Port,
+			database,
+			opt)
+	}
+
 	return fmt.Sprintf(
 		"%s:%s@tcp(%s:%d)/%s?%s",
 		c.Username,
@@ -46,6 +67,9 @@ type EngineOption struct {
 	Slaves                []ConnectionInfo      `json:"slaves"          yaml:"slaves"`
 	SlaveSelectionHandler SlaveSelectionHandler `json:"slave_selection" yaml:"slave_selection"`
 	Options               map[string]string     `json:"options"         yaml:"options"`
+
+	// AllowWriteWithoutWhere allows UPDATE and DELETE without WHERE even if AllRows is not called
+	AllowWriteWithoutWhere bool `json:"allow_write_without_where" yaml:"allow_write_without_where"`
 }
 
 type Option func(o *EngineOption)
@@ -90,6 +114,13 @@ func WithOptions(options map[string]string) Option {
 	}
 }
 
+// WithAllowWriteWithoutWhere opts out of the guard which refuses UPDATE and DELETE without WHERE
+func WithAllowWriteWithoutWhere() Option {
+	return func(o *EngineOption) {
+		o.AllowWriteWithoutWhere = true
+	}
+}
+
 func NewEngine(opts ...Option) (Engine, error) {
 	o := EngineOption{
 		Slaves:                make([]ConnectionInfo, 0),
@@ -104,23 +135,29 @@ func NewEngine(opts ...Option) (Engine, error) {
 
 func NewEngineFromOption(o *EngineOption) (Engine, error) {
 	db, err :=
-		sql.Open(o.Driver, o.Master.dataSourceName(o.Database, o.Options))
+		sql.Open(o.Driver, o.Master.dataSourceName(o.Driver, o.Database, o.Options))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open connection : %w", err)
 	}
 
 	dbs := make([]*sql.DB, 0)
 	for _, slave := range o.Slaves {
-		db, err := sql.Open(o.Driver, slave.dataSourceName(o.Database, o.Options))
+		db, err := sql.Open(o.Driver, slave.dataSourceName(o.Driver, o.Database, o.Options))
 		if err != nil {
 			return nil, fmt.Errorf("failed to open slave connection : %w", err)
 		}
 		dbs = append(dbs, db)
 	}
+	sessionOpts := make([]session.Option, 0)
+	if o.AllowWriteWithoutWhere {
+		sessionOpts = append(sessionOpts, session.AllowWriteWithoutWhere())
+	}
+
 	return &basicEngine{
 		dialect:      strings.ToLower(o.Driver),
 		master:       db,
 		slaves:       dbs,
 		slaveHandler: o.SlaveSelectionHandler,
+		sessionOpts:  sessionOpts,
 	}, nil
 }
@@ -2,6 +2,8 @@ package main
 
 import (
 	"github.com/iancoleman/strcase"
+	"strconv"
+	"strings"
 )
 
 func NewValueEntityGenerator(w Writer) Generator {
@@ -50,16 +52,39 @@ func (g *ValueEntityGenerator) Generate(pkg string, schema *Schema) error {
 				return err
 			}
 
-			g.w.Writeln("%s %s `sqlike:\"%s\"`",
+			g.w.Writeln("%s %s %s",
 				strcase.ToCamel(column.Name),
 				ft,
-				column.Name)
+				structTag(column.Name))
 		}
 
 		g.w.Writeln("}")
 		g.w.Writeln("")
 
+		// Scan targets to fetch the row without reflection
+		g.w.Writeln("func (r *%s) SQLikeScanTargets(columns []string) []interface{} {", strcase.ToCamel(table.Name))
+		g.w.Writeln("    targets := make([]interface{}, len(columns))")
+		g.w.Writeln("    for i, column := range columns {")
+		g.w.Writeln("        switch column {")
+		for _, column := range table.Columns {
+			g.w.Writeln("        case %q:", column.Name)
+			g.w.Writeln("            targets[i] = &r.%s", strcase.ToCamel(column.Name))
+		}
+		g.w.Writeln("        }")
+		g.w.Writeln("    }")
+		g.w.Writeln("    return targets")
+		g.w.Writeln("}")
+		g.w.Writeln("")
 	}
 
 	return g.w.Close()
 }
+
+// structTag カラム名はエスケープして、バッククオートを含む場合は通常の文字列リテラルのタグにする
+func structTag(name string) string {
+	tag := "sqlike:" + strconv.Quote(name)
+	if strings.Contains(tag, "`") {
+		return strconv.Quote(tag)
+	}
+	return "`" + tag + "`"
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// scanTargetsMain 生成したSQLikeScanTargetsでカラムのポインタを取得して値を書き込む
+const scanTargetsMain = `package main
+
+import (
+	"database/sql"
+	"fmt"
+	"gentest/model"
+	"reflect"
+	"time"
+)
+
+func main() {
+	book := &model.Book{}
+	targets := book.SQLikeScanTargets([]string{"title", "unknown", "id", "created_at", "say \"hi\"", "back\x60tick"})
+
+	if err := targets[0].(sql.Scanner).Scan("Hamlet"); err != nil {
+		panic(err)
+	}
+	*targets[2].(*int64) = 10
+	*targets[3].(*time.Time) = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	*targets[4].(*string) = "hi"
+	*targets[5].(*string) = "tick"
+
+	fmt.Printf("%d %v %s %s\n", len(targets), targets[1] == nil, book.Title.String, book.CreatedAt.Format(time.RFC3339))
+	fmt.Printf("%d %s %s\n", book.Id, book.Sayhi, book.Backtick)
+
+	field, _ := reflect.TypeOf(book).Elem().FieldByName("Backtick")
+	fmt.Println(field.Tag.Get("sqlike"))
+}
+`
+
+func TestValueEntityGenerator_ScanTargets(t *testing.T) {
+	goCmd, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command is not found")
+	}
+
+	asserts := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "sqlikegen")
+	if !asserts.Nil(err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	schema := &Schema{
+		DBEngine: "mysql",
+		Database: "library",
+		Schema: []Table{
+			{
+				DBEngine: "mysql",
+				Name:     "book",
+				Columns: []Column{
+					{DBEngine: "mysql", Name: "id", IsNullable: "NO", DataType: "bigint"},
+					{DBEngine: "mysql", Name: "title", IsNullable: "YES", DataType: "varchar"},
+					{DBEngine: "mysql", Name: "created_at", IsNullable: "NO", DataType: "datetime"},
+					{DBEngine: "mysql", Name: `say "hi"`, IsNullable: "NO", DataType: "varchar"},
+					{DBEngine: "mysql", Name: "back`tick", IsNullable: "NO", DataType: "varchar"},
+				},
+			},
+		},
+	}
+
+	asserts.Nil(NewValueEntityGenerator(NewWriter(filepath.Join(dir, "model"), "value.go")).Generate("model", schema))
+	asserts.Nil(ioutil.WriteFile(filepath.Join(dir, "go.mod"), []byte("module gentest\n\ngo 1.13\n"), 0644))
+	asserts.Nil(ioutil.WriteFile(filepath.Join(dir, "main.go"), []byte(scanTargetsMain), 0644))
+
+	cmd := exec.Command(goCmd, "run", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GO111MODULE=on", "GOFLAGS=-mod=mod", "GOWORK=off")
+	out, err := cmd.CombinedOutput()
+	if !asserts.Nil(err, string(out)) {
+		return
+	}
+	asserts.Equal("6 true Hamlet 2020-01-02T03:04:05Z\n10 hi tick\nback`tick\n", string(out))
+}
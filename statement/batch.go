@@ -0,0 +1,225 @@
+package statement
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/tmarcus87/sqlike/dialect"
+	"github.com/tmarcus87/sqlike/logger"
+)
+
+var (
+	ErrorExceedsMaxPacketSize = errors.New("a row exceeds the max packet size")
+)
+
+// chunkableStep 複数行を書き込むStepで、行の範囲を指定して分割できる
+type chunkableStep interface {
+	StatementAcceptor
+	numOfRows() int
+	chunk(from, to int) StatementAcceptor
+}
+
+// BatchStatement is the statement which is split into multiple statements by chunk of rows
+type BatchStatement interface {
+	// StatementsAndBindings returns the statements and bindings of each chunk
+	StatementsAndBindings() ([]string, [][]interface{}, error)
+
+	// Execute executes the statements in order and returns the combined result.
+	// AffectedRows is the sum of all statements and LastInsertId is the one of the first statement,
+	// which is the id of the first inserted row in MySQL.
+	Execute() Result
+}
+
+type BatchStatementImpl struct {
+	target        chunkableStep
+	size          int
+	maxPacketSize int
+	inTx          bool
+
+	built      bool
+	statements []*StatementImpl
+}
+
+func (s *BatchStatementImpl) StatementsAndBindings() ([]string, [][]interface{}, error) {
+	if err := s.buildStatements(); err != nil {
+		return nil, nil, fmt.Errorf("failed to build sql : %w", err)
+	}
+
+	stmts := make([]string, 0)
+	bindings := make([][]interface{}, 0)
+	for _, stmt := range s.statements {
+		stmts = append(stmts, stmt.Statement)
+		bindings = append(bindings, stmt.Bindings)
+	}
+	return stmts, bindings, nil
+}
+
+func (s *BatchStatementImpl) Execute() Result {
+	if err := s.buildStatements(); err != nil {
+		return &BatchResult{err: fmt.Errorf("failed to build sql : %w", err)}
+	}
+
+	q := s.statements[0].queryer
+	exec := q.Execute
+
+	var tx *sql.Tx
+	if r, ok := q.(*RootStep); ok && s.inTx {
+		t, err := r.begin()
+		if err != nil {
+			return &BatchResult{err: fmt.Errorf("failed to begin transaction : %w", err)}
+		}
+		// トランザクション中のセッションの場合はそのまま実行する
+		if t != nil {
+			tx = t
+			exec = func(stmt string, args ...interface{}) (sql.Result, error) {
+				return tx.ExecContext(q.Context(), stmt, args...)
+			}
+		}
+	}
+
+	results := make([]sql.Result, 0)
+	for i, stmt := range s.statements {
+		result, err := exec(stmt.Statement, stmt.Bindings...)
+		if err != nil {
+			if tx != nil {
+				if err := tx.Rollback(); err != nil {
+					logger.Warn(err.Error())
+				}
+			}
+			return &BatchResult{err: fmt.Errorf("failed to execute chunk(%d/%d) : %w", i+1, len(s.statements), err)}
+		}
+		results = append(results, result)
+	}
+
+	if tx != nil {
+		if err := tx.Commit(); err != nil {
+			return &BatchResult{err: fmt.Errorf("failed to commit : %w", err)}
+		}
+	}
+	return &BatchResult{natives: results}
+}
+
+func (s *BatchStatementImpl) buildStatements() error {
+	if s.built {
+		return nil
+	}
+
+	// 行がない場合は VALUES のないINSERTになるため組み立てない
+	n := s.target.numOfRows()
+	if n == 0 {
+		return ErrorNoRecors
+	}
+
+	// 1行分のStatementからプレースホルダの数を求める
+	first := &StatementImpl{sa: s.target.chunk(0, 1)}
+	if err := first.buildStatement(); err != nil {
+		return err
+	}
+	rows := s.size
+	if max, perRow := first.Dialect.MaxPlaceholders(), len(first.Bindings); max > 0 && perRow > 0 {
+		if limit := max / perRow; rows <= 0 || rows > limit {
+			rows = limit
+		}
+	}
+	if rows <= 0 {
+		rows = n
+	}
+
+	statements := make([]*StatementImpl, 0)
+	for from := 0; from < n; {
+		to := from + rows
+		if to > n {
+			to = n
+		}
+
+		stmt, err := s.buildChunk(from, to)
+		if err != nil {
+			return err
+		}
+		// パケットサイズを超える場合は超えなくなるまで行数を半分にして、以降のチャンクにも適用する
+		for s.maxPacketSize > 0 && estimatePacketSize(stmt) > s.maxPacketSize {
+			if to-from == 1 {
+				return fmt.Errorf("row(%d) : %w", from, ErrorExceedsMaxPacketSize)
+			}
+			rows = (to - from) / 2
+			to = from + rows
+			if stmt, err = s.buildChunk(from, to); err != nil {
+				return err
+			}
+		}
+
+		statements = append(statements, stmt)
+		from = to
+	}
+
+	s.statements = statements
+	s.built = true
+
+	logger.Debug("Built %d statements for %d rows", len(statements), n)
+	return nil
+}
+
+func (s *BatchStatementImpl) buildChunk(from, to int) (*StatementImpl, error) {
+	stmt := &StatementImpl{sa: s.target.chunk(from, to)}
+	if err := stmt.buildStatement(); err != nil {
+		return nil, err
+	}
+	return stmt, nil
+}
+
+// estimatePacketSize Statementとバインドする値のおおよそのバイト数を求める
+func estimatePacketSize(stmt *StatementImpl) int {
+	size := len(stmt.Statement)
+	for _, binding := range stmt.Bindings {
+		switch v := binding.(type) {
+		case string:
+			size += len(v)
+		case []byte:
+			size += len(v)
+		default:
+			size += 8
+		}
+	}
+	return size
+}
+
+// BatchResult is the combined result of the batch statement
+type BatchResult struct {
+	natives []sql.Result
+	err     error
+}
+
+func (b BatchResult) Error() error {
+	return b.err
+}
+
+func (b BatchResult) AffectedRows() (int64, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+
+	var affected int64
+	for _, native := range b.natives {
+		n, err := native.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		affected += n
+	}
+	return affected, nil
+}
+
+func (b BatchResult) LastInsertId() (int64, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	return b.natives[0].LastInsertId()
+}
+
+// SkippedRows is not supported since the batch statement is not available with the insert ignore mode
+func (b BatchResult) SkippedRows() (int64, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	return 0, fmt.Errorf("skipped rows of the batch statement is %w", dialect.ErrorNotSupported)
+}
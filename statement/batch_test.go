@@ -0,0 +1,130 @@
+package statement
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/tmarcus87/sqlike/dialect"
+	"github.com/tmarcus87/sqlike/model"
+	"strings"
+	"testing"
+)
+
+func TestBatchStatement_StatementsAndBindings(t *testing.T) {
+	type T1 struct {
+		C1 int32  `sqlike:"c1"`
+		C2 string `sqlike:"c2"`
+	}
+
+	t1 := model.NewTable("t1")
+	c1 := model.NewInt32Column(t1, "c1")
+	c2 := model.NewTextColumn(t1, "c2")
+
+	records := func(n int) []*model.Record {
+		rs := make([]*model.Record, 0)
+		for i := 0; i < n; i++ {
+			rs = append(rs, &model.Record{Value: &T1{C1: int32(i), C2: "abcdefghij"}})
+		}
+		return rs
+	}
+
+	t.Run("Size", func(t *testing.T) {
+		stmts, bindings, err :=
+			NewInsertIntoBranchStep(root(dialect.MySQL), t1).
+				Record(records(5)...).
+				Chunked(2).
+				Build().
+				StatementsAndBindings()
+
+		asserts := assert.New(t)
+		asserts.Nil(err)
+		asserts.Equal([]string{
+			"INSERT INTO `t1` (`c1`, `c2`) VALUES (?, ?), (?, ?)",
+			"INSERT INTO `t1` (`c1`, `c2`) VALUES (?, ?), (?, ?)",
+			"INSERT INTO `t1` (`c1`, `c2`) VALUES (?, ?)",
+		}, stmts)
+		asserts.Equal([][]interface{}{
+			{int32(0), "abcdefghij", int32(1), "abcdefghij"},
+			{int32(2), "abcdefghij", int32(3), "abcdefghij"},
+			{int32(4), "abcdefghij"},
+		}, bindings)
+	})
+
+	t.Run("ValueStructs", func(t *testing.T) {
+		stmts, _, err :=
+			NewInsertIntoBranchStep(root(dialect.Postgres), t1).
+				Columns(c1, c2).
+				ValueStructs(&T1{C1: 1}, &T1{C1: 2}, &T1{C1: 3}).
+				Chunked(2).
+				Build().
+				StatementsAndBindings()
+
+		asserts := assert.New(t)
+		asserts.Nil(err)
+		asserts.Equal([]string{
+			`INSERT INTO "t1" ("c1", "c2") VALUES ($1, $2), ($3, $4)`,
+			`INSERT INTO "t1" ("c1", "c2") VALUES ($1, $2)`,
+		}, stmts)
+	})
+
+	t.Run("MaxPlaceholders", func(t *testing.T) {
+		stmts, bindings, err :=
+			NewInsertIntoBranchStep(root(dialect.Sqlite3), t1).
+				Record(records(20000)...).
+				Chunked(0).
+				Build().
+				StatementsAndBindings()
+
+		asserts := assert.New(t)
+		asserts.Nil(err)
+		asserts.Len(stmts, 2)
+		asserts.Len(bindings[0], 32766)
+		asserts.Len(bindings[1], 40000-32766)
+	})
+
+	t.Run("MaxPacketSize", func(t *testing.T) {
+		stmts, _, err :=
+			NewInsertIntoBranchStep(root(dialect.MySQL), t1).
+				Record(records(8)...).
+				Chunked(0).
+				MaxPacketSize(100).
+				Build().
+				StatementsAndBindings()
+
+		asserts := assert.New(t)
+		asserts.Nil(err)
+		asserts.Len(stmts, 4)
+		for _, stmt := range stmts {
+			asserts.Equal(1, strings.Count(stmt, "), ("))
+		}
+
+		_, _, err =
+			NewInsertIntoBranchStep(root(dialect.MySQL), t1).
+				Record(records(1)...).
+				Chunked(0).
+				MaxPacketSize(10).
+				Build().
+				StatementsAndBindings()
+		asserts.True(errors.Is(err, ErrorExceedsMaxPacketSize))
+	})
+
+	t.Run("NoRecords", func(t *testing.T) {
+		_, _, err :=
+			NewInsertIntoBranchStep(root(dialect.MySQL), t1).
+				Record().
+				Chunked(10).
+				Build().
+				StatementsAndBindings()
+
+		asserts := assert.New(t)
+		asserts.True(errors.Is(err, ErrorNoRecors))
+
+		result :=
+			NewInsertIntoBranchStep(root(dialect.MySQL), t1).
+				Columns(c1, c2).
+				ValueStructs().
+				Chunked(10).
+				Build().
+				Execute()
+		asserts.True(errors.Is(result.Error(), ErrorNoRecors))
+	})
+}
@@ -0,0 +1,135 @@
+package statement
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/tmarcus87/sqlike/logger"
+	"reflect"
+)
+
+var (
+	// ErrorStopIteration is returned by the function passed to Iterate for terminating the iteration.
+	// Iterate returns nil in this case.
+	ErrorStopIteration = errors.New("stop iteration")
+)
+
+// Cursor iterates the result set row by row without materializing it into memory.
+//
+//	cur, err := stmt.Cursor(&book)
+//	if err != nil { ... }
+//	defer cur.Close()
+//	for cur.Next() {
+//		if err := cur.Scan(); err != nil { ... }
+//		// use book
+//	}
+//	if err := cur.Err(); err != nil { ... }
+type Cursor interface {
+	// Next prepares the next row. It returns false when there are no more rows or an error occurred.
+	Next() bool
+
+	// Scan scans the current row into the struct given to Statement.Cursor.
+	Scan() error
+
+	// Err returns the error encountered during the iteration.
+	Err() error
+
+	// Close closes the underlying rows. It is safe to call Close multiple times.
+	Close() error
+}
+
+type rowsCursor struct {
+	rows   *sql.Rows
+	vptrs  []interface{}
+	value  interface{}
+	closed bool
+}
+
+func (c *rowsCursor) Next() bool {
+	return c.rows.Next()
+}
+
+func (c *rowsCursor) Scan() error {
+	if err := c.rows.Scan(c.vptrs...); err != nil {
+		return err
+	}
+	logger.Debug("Fetch record to struct : %+v", c.value)
+	return nil
+}
+
+func (c *rowsCursor) Err() error {
+	return c.rows.Err()
+}
+
+func (c *rowsCursor) Close() error {
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	return c.rows.Close()
+}
+
+func (s *StatementImpl) Cursor(p interface{}) (Cursor, error) {
+	if err := s.buildStatement(); err != nil {
+		return nil, fmt.Errorf("failed to build sql : %w", err)
+	}
+
+	// 入力型をチェック
+	v := reflect.ValueOf(p)
+	if v.Kind() != reflect.Ptr {
+		return nil, ErrorMustBeAPtr
+	}
+	if v.IsNil() {
+		return nil, ErrorMustBeANonNilPtr
+	}
+	if v.Elem().Kind() != reflect.Struct {
+		return nil, ErrorMustBeAStructPtr
+	}
+
+	rows, err := s.queryer.Query(s.Statement, s.Bindings...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query : %w", err)
+	}
+
+	names, err := rows.Columns()
+	if err != nil {
+		_ = rows.Close()
+		return nil, err
+	}
+
+	// 同じ値を使い回すため、フィールドのポインタは一度だけ求める
+	vptrs, err := s.toFieldPtr(p, names)
+	if err != nil {
+		_ = rows.Close()
+		return nil, err
+	}
+
+	return &rowsCursor{rows: rows, vptrs: vptrs, value: p}, nil
+}
+
+func (s *StatementImpl) Iterate(p interface{}, fn func() error) error {
+	cur, err := s.Cursor(p)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := cur.Close(); err != nil {
+			logger.Warn(err.Error())
+		}
+	}()
+
+	for cur.Next() {
+		if err := cur.Scan(); err != nil {
+			return err
+		}
+		if err := fn(); err != nil {
+			if err == ErrorStopIteration {
+				return nil
+			}
+			return err
+		}
+	}
+
+	return cur.Err()
+}
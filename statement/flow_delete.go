@@ -4,6 +4,12 @@ import "github.com/tmarcus87/sqlike/model"
 
 type DeleteFromBranchStep interface {
 	Where(conditions ...model.Condition) DeleteWhereBranchStep
+	AllRows() DeleteWhereBranchStep
+	InnerJoin(table model.Table, conditions ...model.Condition) DeleteJoinBranchStep
+	LeftOuterJoin(table model.Table, conditions ...model.Condition) DeleteJoinBranchStep
+	OrderBy(orders ...*model.SortOrder) WriteOrderByBranchStep
+	Limit(limit int32) WriteLimitBranchStep
+	Returning(columns ...model.ColumnField) ReturningBranchStep
 	Build() Statement
 }
 
@@ -17,7 +23,7 @@ func NewDeleteFromBranchStep(parent StatementAcceptor, table model.Table) Delete
 }
 
 type deleteFromBranchStepImpl struct {
-	parent StatementAcceptor
+	parent *DeleteFromStep
 }
 
 func (s *deleteFromBranchStepImpl) Parent() StatementAcceptor {
@@ -35,11 +41,56 @@ func (s *deleteFromBranchStepImpl) Where(conditions ...model.Condition) DeleteWh
 	}
 }
 
+func (s *deleteFromBranchStepImpl) AllRows() DeleteWhereBranchStep {
+	return &deleteWhereBranchStepImpl{
+		parent: &AllRowsStep{
+			parent: s,
+		},
+	}
+}
+
+// joined 削除対象のテーブルを指定するDeleteFromStepに置き換えて結合する
+func (s *deleteFromBranchStepImpl) joined() StatementAcceptor {
+	return &DeleteFromStep{
+		parent: s.parent.parent,
+		table:  s.parent.table,
+		joined: true,
+	}
+}
+
+func (s *deleteFromBranchStepImpl) InnerJoin(table model.Table, conditions ...model.Condition) DeleteJoinBranchStep {
+	return newDeleteJoinBranchStep(s.joined(), table, conditions, "INNER JOIN")
+}
+
+func (s *deleteFromBranchStepImpl) LeftOuterJoin(table model.Table, conditions ...model.Condition) DeleteJoinBranchStep {
+	return newDeleteJoinBranchStep(s.joined(), table, conditions, "LEFT OUTER JOIN")
+}
+
+func (s *deleteFromBranchStepImpl) OrderBy(orders ...*model.SortOrder) WriteOrderByBranchStep {
+	return &writeOrderByBranchStepImpl{
+		parent: &WriteOrderByStep{
+			parent: s,
+			orders: orders,
+		},
+	}
+}
+
+func (s *deleteFromBranchStepImpl) Limit(limit int32) WriteLimitBranchStep {
+	return newWriteLimitBranchStep(s, limit)
+}
+
 func (s *deleteFromBranchStepImpl) Build() Statement {
 	return NewStatementBuilder(s)
 }
 
+func (s *deleteFromBranchStepImpl) Returning(columns ...model.ColumnField) ReturningBranchStep {
+	return newReturningBranchStep(s, columns)
+}
+
 type DeleteWhereBranchStep interface {
+	OrderBy(orders ...*model.SortOrder) WriteOrderByBranchStep
+	Limit(limit int32) WriteLimitBranchStep
+	Returning(columns ...model.ColumnField) ReturningBranchStep
 	Build() Statement
 }
 
@@ -53,6 +104,100 @@ func (s *deleteWhereBranchStepImpl) Parent() StatementAcceptor {
 
 func (s *deleteWhereBranchStepImpl) Accept(*StatementImpl) error { return nil }
 
+func (s *deleteWhereBranchStepImpl) OrderBy(orders ...*model.SortOrder) WriteOrderByBranchStep {
+	return &writeOrderByBranchStepImpl{
+		parent: &WriteOrderByStep{
+			parent: s,
+			orders: orders,
+		},
+	}
+}
+
+func (s *deleteWhereBranchStepImpl) Limit(limit int32) WriteLimitBranchStep {
+	return newWriteLimitBranchStep(s, limit)
+}
+
 func (s *deleteWhereBranchStepImpl) Build() Statement {
 	return NewStatementBuilder(s)
 }
+
+func (s *deleteWhereBranchStepImpl) Returning(columns ...model.ColumnField) ReturningBranchStep {
+	return newReturningBranchStep(s, columns)
+}
+
+// DeleteJoinBranchStep is the multi table DELETE such as `DELETE a FROM a INNER JOIN b ON ...`, which is available in MySQL
+type DeleteJoinBranchStep interface {
+	InnerJoin(table model.Table, conditions ...model.Condition) DeleteJoinBranchStep
+	LeftOuterJoin(table model.Table, conditions ...model.Condition) DeleteJoinBranchStep
+	Where(conditions ...model.Condition) DeleteJoinWhereBranchStep
+	AllRows() DeleteJoinWhereBranchStep
+	Build() Statement
+}
+
+func newDeleteJoinBranchStep(parent StatementAcceptor, table model.Table, conditions []model.Condition, joinType string) DeleteJoinBranchStep {
+	return &deleteJoinBranchStepImpl{
+		parent: &WriteJoinStep{
+			parent:     parent,
+			table:      table,
+			conditions: conditions,
+			joinType:   joinType,
+		},
+	}
+}
+
+type deleteJoinBranchStepImpl struct {
+	parent StatementAcceptor
+}
+
+func (s *deleteJoinBranchStepImpl) Parent() StatementAcceptor {
+	return s.parent
+}
+
+func (s *deleteJoinBranchStepImpl) Accept(*StatementImpl) error { return nil }
+
+func (s *deleteJoinBranchStepImpl) InnerJoin(table model.Table, conditions ...model.Condition) DeleteJoinBranchStep {
+	return newDeleteJoinBranchStep(s, table, conditions, "INNER JOIN")
+}
+
+func (s *deleteJoinBranchStepImpl) LeftOuterJoin(table model.Table, conditions ...model.Condition) DeleteJoinBranchStep {
+	return newDeleteJoinBranchStep(s, table, conditions, "LEFT OUTER JOIN")
+}
+
+func (s *deleteJoinBranchStepImpl) Where(conditions ...model.Condition) DeleteJoinWhereBranchStep {
+	return &deleteJoinWhereBranchStepImpl{
+		parent: &WhereStep{
+			parent:     s,
+			conditions: conditions,
+		},
+	}
+}
+
+func (s *deleteJoinBranchStepImpl) AllRows() DeleteJoinWhereBranchStep {
+	return &deleteJoinWhereBranchStepImpl{
+		parent: &AllRowsStep{
+			parent: s,
+		},
+	}
+}
+
+func (s *deleteJoinBranchStepImpl) Build() Statement {
+	return NewStatementBuilder(s)
+}
+
+type DeleteJoinWhereBranchStep interface {
+	Build() Statement
+}
+
+type deleteJoinWhereBranchStepImpl struct {
+	parent StatementAcceptor
+}
+
+func (s *deleteJoinWhereBranchStepImpl) Parent() StatementAcceptor {
+	return s.parent
+}
+
+func (s *deleteJoinWhereBranchStepImpl) Accept(*StatementImpl) error { return nil }
+
+func (s *deleteJoinWhereBranchStepImpl) Build() Statement {
+	return NewStatementBuilder(s)
+}
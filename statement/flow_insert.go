@@ -88,6 +88,7 @@ func (s *insertIntoColumnBranchStepImpl) Select(columns ...model.ColumnField) Se
 }
 
 type InsertIntoValuesBranchStep interface {
+	Returning(columns ...model.ColumnField) ReturningBranchStep
 	Build() Statement
 	Values(values ...interface{}) InsertIntoValuesBranchStep
 	OnDuplicateKeyIgnore() InsertOnDuplicateKeyIgnoreBranchStep
@@ -108,6 +109,10 @@ func (s *insertIntoValuesBranchStepImpl) Build() Statement {
 	return NewStatementBuilder(s)
 }
 
+func (s *insertIntoValuesBranchStepImpl) Returning(columns ...model.ColumnField) ReturningBranchStep {
+	return newReturningBranchStep(s, columns)
+}
+
 func (s *insertIntoValuesBranchStepImpl) Values(values ...interface{}) InsertIntoValuesBranchStep {
 	return &insertIntoValuesBranchStepImpl{
 		parent: &InsertIntoValuesStep{
@@ -134,9 +139,11 @@ func (s *insertIntoValuesBranchStepImpl) OnDuplicateKeyUpdate() InsertOnDuplicat
 }
 
 type InsertIntoValueStructsBranchStep interface {
+	Returning(columns ...model.ColumnField) ReturningBranchStep
 	Build() Statement
 	OnDuplicateKeyIgnore() InsertOnDuplicateKeyIgnoreBranchStep
 	OnDuplicateKeyUpdate() InsertOnDuplicateKeyUpdateBranchStep
+	Chunked(size int) InsertChunkedBranchStep
 }
 
 type insertIntoValueStructsBranchStepImpl struct {
@@ -153,6 +160,17 @@ func (s *insertIntoValueStructsBranchStepImpl) Build() Statement {
 	return NewStatementBuilder(s)
 }
 
+func (s *insertIntoValueStructsBranchStepImpl) Returning(columns ...model.ColumnField) ReturningBranchStep {
+	return newReturningBranchStep(s, columns)
+}
+
+func (s *insertIntoValueStructsBranchStepImpl) Chunked(size int) InsertChunkedBranchStep {
+	return &insertChunkedBranchStepImpl{
+		target: s.parent.(chunkableStep),
+		size:   size,
+	}
+}
+
 func (s *insertIntoValueStructsBranchStepImpl) OnDuplicateKeyIgnore() InsertOnDuplicateKeyIgnoreBranchStep {
 	return &insertOnDuplicateKeyIgnoreBranchStepImpl{
 		parent: &InsertOnDuplicateKeyIgnoreStep{
@@ -170,9 +188,11 @@ func (s *insertIntoValueStructsBranchStepImpl) OnDuplicateKeyUpdate() InsertOnDu
 }
 
 type InsertIntoValueRecordBranchStep interface {
+	Returning(columns ...model.ColumnField) ReturningBranchStep
 	Build() Statement
 	OnDuplicateKeyIgnore() InsertOnDuplicateKeyIgnoreBranchStep
 	OnDuplicateKeyUpdate() InsertOnDuplicateKeyUpdateBranchStep
+	Chunked(size int) InsertChunkedBranchStep
 }
 
 type insertIntoValueRecordBranchStepImpl struct {
@@ -189,6 +209,17 @@ func (s *insertIntoValueRecordBranchStepImpl) Build() Statement {
 	return NewStatementBuilder(s)
 }
 
+func (s *insertIntoValueRecordBranchStepImpl) Returning(columns ...model.ColumnField) ReturningBranchStep {
+	return newReturningBranchStep(s, columns)
+}
+
+func (s *insertIntoValueRecordBranchStepImpl) Chunked(size int) InsertChunkedBranchStep {
+	return &insertChunkedBranchStepImpl{
+		target: s.parent.(chunkableStep),
+		size:   size,
+	}
+}
+
 func (s *insertIntoValueRecordBranchStepImpl) OnDuplicateKeyIgnore() InsertOnDuplicateKeyIgnoreBranchStep {
 	return &insertOnDuplicateKeyIgnoreBranchStepImpl{
 		parent: &InsertOnDuplicateKeyIgnoreStep{
@@ -206,6 +237,7 @@ func (s *insertIntoValueRecordBranchStepImpl) OnDuplicateKeyUpdate() InsertOnDup
 }
 
 type InsertOnDuplicateKeyIgnoreBranchStep interface {
+	Returning(columns ...model.ColumnField) ReturningBranchStep
 	Build() Statement
 }
 
@@ -223,8 +255,13 @@ func (s *insertOnDuplicateKeyIgnoreBranchStepImpl) Build() Statement {
 	return NewStatementBuilder(s)
 }
 
+func (s *insertOnDuplicateKeyIgnoreBranchStepImpl) Returning(columns ...model.ColumnField) ReturningBranchStep {
+	return newReturningBranchStep(s, columns)
+}
+
 type InsertOnDuplicateKeyUpdateBranchStep interface {
 	SetValue(columnValue model.ColumnValue) InsertOnDuplicateKeyUpdateSetBranchStep
+	SetExpr(column model.Column, expr model.Expression) InsertOnDuplicateKeyUpdateSetBranchStep
 	SetRecord(record *model.Record) InsertOnDuplicateKeyUpdateSetRecordBranchStep
 }
 
@@ -247,6 +284,10 @@ func (s *insertOnDuplicateKeyUpdateBranchStepImpl) SetValue(columnValue model.Co
 	}
 }
 
+func (s *insertOnDuplicateKeyUpdateBranchStepImpl) SetExpr(column model.Column, expr model.Expression) InsertOnDuplicateKeyUpdateSetBranchStep {
+	return s.SetValue(model.ExprValue(column, expr))
+}
+
 func (s *insertOnDuplicateKeyUpdateBranchStepImpl) SetRecord(record *model.Record) InsertOnDuplicateKeyUpdateSetRecordBranchStep {
 	return &insertOnDuplicateKeyUpdateSetRecordBranchStepImpl{
 		parent: &InsertOnDuplicateKeyUpdateSetRecordStep{
@@ -257,8 +298,10 @@ func (s *insertOnDuplicateKeyUpdateBranchStepImpl) SetRecord(record *model.Recor
 }
 
 type InsertOnDuplicateKeyUpdateSetBranchStep interface {
+	Returning(columns ...model.ColumnField) ReturningBranchStep
 	Build() Statement
 	SetValue(columnValue model.ColumnValue) InsertOnDuplicateKeyUpdateSetBranchStep
+	SetExpr(column model.Column, expr model.Expression) InsertOnDuplicateKeyUpdateSetBranchStep
 }
 
 type insertOnDuplicateKeyUpdateSetBranchStepImpl struct {
@@ -275,6 +318,10 @@ func (s *insertOnDuplicateKeyUpdateSetBranchStepImpl) Build() Statement {
 	return NewStatementBuilder(s)
 }
 
+func (s *insertOnDuplicateKeyUpdateSetBranchStepImpl) Returning(columns ...model.ColumnField) ReturningBranchStep {
+	return newReturningBranchStep(s, columns)
+}
+
 func (s *insertOnDuplicateKeyUpdateSetBranchStepImpl) SetValue(columnValue model.ColumnValue) InsertOnDuplicateKeyUpdateSetBranchStep {
 	return &insertOnDuplicateKeyUpdateSetBranchStepImpl{
 		parent: &InsertOnDuplicateKeyUpdateSetStep{
@@ -284,7 +331,12 @@ func (s *insertOnDuplicateKeyUpdateSetBranchStepImpl) SetValue(columnValue model
 	}
 }
 
+func (s *insertOnDuplicateKeyUpdateSetBranchStepImpl) SetExpr(column model.Column, expr model.Expression) InsertOnDuplicateKeyUpdateSetBranchStep {
+	return s.SetValue(model.ExprValue(column, expr))
+}
+
 type InsertOnDuplicateKeyUpdateSetRecordBranchStep interface {
+	Returning(columns ...model.ColumnField) ReturningBranchStep
 	Build() Statement
 }
 
@@ -301,3 +353,48 @@ func (s *insertOnDuplicateKeyUpdateSetRecordBranchStepImpl) Accept(*StatementImp
 func (s *insertOnDuplicateKeyUpdateSetRecordBranchStepImpl) Build() Statement {
 	return NewStatementBuilder(s)
 }
+
+func (s *insertOnDuplicateKeyUpdateSetRecordBranchStepImpl) Returning(columns ...model.ColumnField) ReturningBranchStep {
+	return newReturningBranchStep(s, columns)
+}
+
+// InsertChunkedBranchStep splits the rows to insert into multiple statements.
+// The number of rows in a statement is limited by the size of the chunk and the max placeholders of the dialect,
+// and the size is determined only by the dialect if it is 0 or less.
+type InsertChunkedBranchStep interface {
+	// MaxPacketSize limits the estimated size of a statement and bindings, such as max_allowed_packet of MySQL
+	MaxPacketSize(bytes int) InsertChunkedBranchStep
+
+	// InTx executes the statements in a transaction, unless the session is already in a transaction
+	InTx() InsertChunkedBranchStep
+
+	Build() BatchStatement
+}
+
+type insertChunkedBranchStepImpl struct {
+	target        chunkableStep
+	size          int
+	maxPacketSize int
+	inTx          bool
+}
+
+func (s *insertChunkedBranchStepImpl) MaxPacketSize(bytes int) InsertChunkedBranchStep {
+	c := *s
+	c.maxPacketSize = bytes
+	return &c
+}
+
+func (s *insertChunkedBranchStepImpl) InTx() InsertChunkedBranchStep {
+	c := *s
+	c.inTx = true
+	return &c
+}
+
+func (s *insertChunkedBranchStepImpl) Build() BatchStatement {
+	return &BatchStatementImpl{
+		target:        s.target,
+		size:          s.size,
+		maxPacketSize: s.maxPacketSize,
+		inTx:          s.inTx,
+	}
+}
@@ -1,6 +1,7 @@
 package statement
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/tmarcus87/sqlike/dialect"
 	"github.com/tmarcus87/sqlike/model"
@@ -24,13 +25,59 @@ func TestInsertIntoBranchStep(t *testing.T) {
 
 		asserts := assert.New(t)
 		asserts.Nil(err)
-		asserts.Equal("INSERT INTO `t1` (`c1`, `c2`) VALUES (?, ?) ON DUPLICATE KEY UPDATE SET `c2` = ?", stmt)
+		asserts.Equal("INSERT INTO `t1` (`c1`, `c2`) VALUES (?, ?) ON DUPLICATE KEY UPDATE `c2` = ?", stmt)
 		asserts.Len(bindings, 3)
 		asserts.Equal(1, bindings[0])
 		asserts.Equal(2, bindings[1])
 		asserts.Equal(int32(3), bindings[2])
 	})
 
+	t.Run("InsertOnDuplicateKeyUpdateSetExpr", func(t *testing.T) {
+		tests := []struct {
+			Dialect string
+			Expect  string
+		}{
+			{Dialect: dialect.MySQL, Expect: "INSERT INTO `t1` (`c1`, `c2`) VALUES (?, ?) ON DUPLICATE KEY UPDATE `c2` = (`t1`.`c2` + VALUES(`c2`)), `c1` = ?"},
+			{Dialect: dialect.Sqlite3, Expect: `INSERT INTO "t1" ("c1", "c2") VALUES (?, ?) ON CONFLICT DO UPDATE SET "c2" = ("t1"."c2" + excluded."c2"), "c1" = ?`},
+		}
+
+		for _, test := range tests {
+			stmt, bindings, err :=
+				NewInsertIntoBranchStep(root(test.Dialect), t1).
+					Columns(c1, c2).
+					Values(1, 2).
+					OnDuplicateKeyUpdate().
+					SetExpr(c2, model.ColumnOf(c2).Plus(model.InsertedValue(c2))).
+					SetValue(c1.Value(3)).
+					Build().
+					StatementAndBindings()
+
+			asserts := assert.New(t)
+			asserts.Nil(err)
+			asserts.Equal(test.Expect, stmt)
+			asserts.Equal([]interface{}{1, 2, int32(3)}, bindings)
+		}
+	})
+
+	t.Run("InsertOnDuplicateKeyUpdateSetTwoValues", func(t *testing.T) {
+		stmt, bindings, err :=
+			NewInsertIntoBranchStep(root(dialect.MySQL), t1).
+				Columns(c1, c2).
+				Values(1, 2).
+				OnDuplicateKeyUpdate().
+				SetValue(c1.Value(3)).
+				SetValue(c2.Value(4)).
+				Build().
+				StatementAndBindings()
+
+		asserts := assert.New(t)
+		asserts.Nil(err)
+		asserts.Equal("INSERT INTO `t1` (`c1`, `c2`) VALUES (?, ?) ON DUPLICATE KEY UPDATE `c1` = ?, `c2` = ?", stmt)
+		asserts.Len(bindings, 4)
+		asserts.Equal(int32(3), bindings[2])
+		asserts.Equal(int32(4), bindings[3])
+	})
+
 	t.Run("InsertOnDuplicateKeyUpdateSetRecord", func(t *testing.T) {
 		type Value struct {
 			C1 int32 `sqlike:"c1"`
@@ -54,11 +101,41 @@ func TestInsertIntoBranchStep(t *testing.T) {
 
 		asserts := assert.New(t)
 		asserts.Nil(err)
-		asserts.Equal("INSERT INTO `t1` (`c1`, `c2`) VALUES (?, ?) ON DUPLICATE KEY UPDATE SET `c2` = ?", stmt)
+		asserts.Equal("INSERT INTO `t1` (`c1`, `c2`) VALUES (?, ?) ON DUPLICATE KEY UPDATE `c2` = ?", stmt)
 		asserts.Len(bindings, 3)
 		asserts.Equal(1, bindings[0])
 		asserts.Equal(2, bindings[1])
 		asserts.Equal(int32(4), bindings[2])
 	})
 
+	t.Run("PostgresOnConflictDoNothing", func(t *testing.T) {
+		stmt, bindings, err :=
+			NewInsertIntoBranchStep(root(dialect.Postgres), t1).
+				Columns(c1, c2).
+				Values(1, 2).
+				OnDuplicateKeyIgnore().
+				Build().
+				StatementAndBindings()
+
+		asserts := assert.New(t)
+		asserts.Nil(err)
+		asserts.Equal(`INSERT INTO "t1" ("c1", "c2") VALUES ($1, $2) ON CONFLICT DO NOTHING`, stmt)
+		asserts.Len(bindings, 2)
+	})
+
+	t.Run("PostgresOnConflictDoUpdate", func(t *testing.T) {
+		// PostgreSQLではDO UPDATEに競合対象が必要なため、Upsertを使う
+		_, _, err :=
+			NewInsertIntoBranchStep(root(dialect.Postgres), t1).
+				Columns(c1, c2).
+				Values(1, 2).
+				OnDuplicateKeyUpdate().
+				SetValue(c2.Value(3)).
+				Build().
+				StatementAndBindings()
+
+		asserts := assert.New(t)
+		asserts.True(errors.Is(err, dialect.ErrorNotSupported))
+		asserts.Contains(err.Error(), "OnConflictUpdate")
+	})
 }
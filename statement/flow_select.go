@@ -1,6 +1,7 @@
 package statement
 
 import (
+	"github.com/tmarcus87/sqlike/dialect"
 	"github.com/tmarcus87/sqlike/model"
 )
 
@@ -111,6 +112,10 @@ type SelectFromBranchStep interface {
 	GroupBy(columns ...model.ColumnField) SelectFromGroupByBranchStep
 	OrderBy(orders ...*model.SortOrder) SelectFromOrderByBranchStep
 	LimitAndOffset(limit int32, offset int64) SelectFromLimitAndOffsetBranchStep
+	Union(query model.SubQuery) SelectCompoundBranchStep
+	UnionAll(query model.SubQuery) SelectCompoundBranchStep
+	Intersect(query model.SubQuery) SelectCompoundBranchStep
+	Except(query model.SubQuery) SelectCompoundBranchStep
 }
 
 func NewSelectFromBranchStep(parent StatementAcceptor, table model.Table) SelectFromBranchStep {
@@ -211,6 +216,22 @@ func (s *selectFromBranchStepImpl) LimitAndOffset(limit int32, offset int64) Sel
 	}
 }
 
+func (s *selectFromBranchStepImpl) Union(query model.SubQuery) SelectCompoundBranchStep {
+	return newSelectCompoundBranchStep(s, dialect.SetOperatorUnion, query)
+}
+
+func (s *selectFromBranchStepImpl) UnionAll(query model.SubQuery) SelectCompoundBranchStep {
+	return newSelectCompoundBranchStep(s, dialect.SetOperatorUnionAll, query)
+}
+
+func (s *selectFromBranchStepImpl) Intersect(query model.SubQuery) SelectCompoundBranchStep {
+	return newSelectCompoundBranchStep(s, dialect.SetOperatorIntersect, query)
+}
+
+func (s *selectFromBranchStepImpl) Except(query model.SubQuery) SelectCompoundBranchStep {
+	return newSelectCompoundBranchStep(s, dialect.SetOperatorExcept, query)
+}
+
 type SelectFromJoinBranchStep interface {
 	Build() Statement
 	LeftOuterJoin(table model.Table, conditions ...model.Condition) SelectFromJoinBranchStep
@@ -220,6 +241,10 @@ type SelectFromJoinBranchStep interface {
 	GroupBy(columns ...model.ColumnField) SelectFromGroupByBranchStep
 	OrderBy(orders ...*model.SortOrder) SelectFromOrderByBranchStep
 	LimitAndOffset(limit int32, offset int64) SelectFromLimitAndOffsetBranchStep
+	Union(query model.SubQuery) SelectCompoundBranchStep
+	UnionAll(query model.SubQuery) SelectCompoundBranchStep
+	Intersect(query model.SubQuery) SelectCompoundBranchStep
+	Except(query model.SubQuery) SelectCompoundBranchStep
 }
 
 type selectFromJoinBranchStepImpl struct {
@@ -307,11 +332,32 @@ func (s *selectFromJoinBranchStepImpl) LimitAndOffset(limit int32, offset int64)
 	}
 }
 
+func (s *selectFromJoinBranchStepImpl) Union(query model.SubQuery) SelectCompoundBranchStep {
+	return newSelectCompoundBranchStep(s, dialect.SetOperatorUnion, query)
+}
+
+func (s *selectFromJoinBranchStepImpl) UnionAll(query model.SubQuery) SelectCompoundBranchStep {
+	return newSelectCompoundBranchStep(s, dialect.SetOperatorUnionAll, query)
+}
+
+func (s *selectFromJoinBranchStepImpl) Intersect(query model.SubQuery) SelectCompoundBranchStep {
+	return newSelectCompoundBranchStep(s, dialect.SetOperatorIntersect, query)
+}
+
+func (s *selectFromJoinBranchStepImpl) Except(query model.SubQuery) SelectCompoundBranchStep {
+	return newSelectCompoundBranchStep(s, dialect.SetOperatorExcept, query)
+}
+
 type SelectFromWhereBranchStep interface {
 	Build() Statement
+	SeekAfter(orders []*model.SortOrder, lastValues ...interface{}) SelectFromSeekBranchStep
 	GroupBy(columns ...model.ColumnField) SelectFromGroupByBranchStep
 	OrderBy(orders ...*model.SortOrder) SelectFromOrderByBranchStep
 	LimitAndOffset(limit int32, offset int64) SelectFromLimitAndOffsetBranchStep
+	Union(query model.SubQuery) SelectCompoundBranchStep
+	UnionAll(query model.SubQuery) SelectCompoundBranchStep
+	Intersect(query model.SubQuery) SelectCompoundBranchStep
+	Except(query model.SubQuery) SelectCompoundBranchStep
 }
 
 type selectFromWhereBranchStepImpl struct {
@@ -346,6 +392,16 @@ func (s *selectFromWhereBranchStepImpl) OrderBy(orders ...*model.SortOrder) Sele
 	}
 }
 
+func (s *selectFromWhereBranchStepImpl) SeekAfter(orders []*model.SortOrder, lastValues ...interface{}) SelectFromSeekBranchStep {
+	return &selectFromSeekBranchStepImpl{
+		parent: &SelectSeekStep{
+			parent: s,
+			orders: orders,
+			values: lastValues,
+		},
+	}
+}
+
 func (s *selectFromWhereBranchStepImpl) LimitAndOffset(limit int32, offset int64) SelectFromLimitAndOffsetBranchStep {
 	return &selectFromLimitAndOffsetBranchStepImpl{
 		parent: &SelectLimitOffsetStep{
@@ -356,10 +412,31 @@ func (s *selectFromWhereBranchStepImpl) LimitAndOffset(limit int32, offset int64
 	}
 }
 
+func (s *selectFromWhereBranchStepImpl) Union(query model.SubQuery) SelectCompoundBranchStep {
+	return newSelectCompoundBranchStep(s, dialect.SetOperatorUnion, query)
+}
+
+func (s *selectFromWhereBranchStepImpl) UnionAll(query model.SubQuery) SelectCompoundBranchStep {
+	return newSelectCompoundBranchStep(s, dialect.SetOperatorUnionAll, query)
+}
+
+func (s *selectFromWhereBranchStepImpl) Intersect(query model.SubQuery) SelectCompoundBranchStep {
+	return newSelectCompoundBranchStep(s, dialect.SetOperatorIntersect, query)
+}
+
+func (s *selectFromWhereBranchStepImpl) Except(query model.SubQuery) SelectCompoundBranchStep {
+	return newSelectCompoundBranchStep(s, dialect.SetOperatorExcept, query)
+}
+
 type SelectFromGroupByBranchStep interface {
 	Build() Statement
+	Having(conditions ...model.Condition) SelectFromHavingBranchStep
 	OrderBy(orders ...*model.SortOrder) SelectFromOrderByBranchStep
 	LimitAndOffset(limit int32, offset int64) SelectFromLimitAndOffsetBranchStep
+	Union(query model.SubQuery) SelectCompoundBranchStep
+	UnionAll(query model.SubQuery) SelectCompoundBranchStep
+	Intersect(query model.SubQuery) SelectCompoundBranchStep
+	Except(query model.SubQuery) SelectCompoundBranchStep
 }
 
 type selectFromGroupByBranchStepImpl struct {
@@ -376,6 +453,15 @@ func (s *selectFromGroupByBranchStepImpl) Build() Statement {
 	return NewStatementBuilder(s)
 }
 
+func (s *selectFromGroupByBranchStepImpl) Having(conditions ...model.Condition) SelectFromHavingBranchStep {
+	return &selectFromHavingBranchStepImpl{
+		parent: &SelectHavingStep{
+			parent:     s,
+			conditions: conditions,
+		},
+	}
+}
+
 func (s *selectFromGroupByBranchStepImpl) OrderBy(orders ...*model.SortOrder) SelectFromOrderByBranchStep {
 	return &selectFromOrderByBranchStepImpl{
 		parent: &SelectOrderByStep{
@@ -395,13 +481,91 @@ func (s *selectFromGroupByBranchStepImpl) LimitAndOffset(limit int32, offset int
 	}
 }
 
+func (s *selectFromGroupByBranchStepImpl) Union(query model.SubQuery) SelectCompoundBranchStep {
+	return newSelectCompoundBranchStep(s, dialect.SetOperatorUnion, query)
+}
+
+func (s *selectFromGroupByBranchStepImpl) UnionAll(query model.SubQuery) SelectCompoundBranchStep {
+	return newSelectCompoundBranchStep(s, dialect.SetOperatorUnionAll, query)
+}
+
+func (s *selectFromGroupByBranchStepImpl) Intersect(query model.SubQuery) SelectCompoundBranchStep {
+	return newSelectCompoundBranchStep(s, dialect.SetOperatorIntersect, query)
+}
+
+func (s *selectFromGroupByBranchStepImpl) Except(query model.SubQuery) SelectCompoundBranchStep {
+	return newSelectCompoundBranchStep(s, dialect.SetOperatorExcept, query)
+}
+
+type SelectFromHavingBranchStep interface {
+	Build() Statement
+	OrderBy(orders ...*model.SortOrder) SelectFromOrderByBranchStep
+	LimitAndOffset(limit int32, offset int64) SelectFromLimitAndOffsetBranchStep
+	Union(query model.SubQuery) SelectCompoundBranchStep
+	UnionAll(query model.SubQuery) SelectCompoundBranchStep
+	Intersect(query model.SubQuery) SelectCompoundBranchStep
+	Except(query model.SubQuery) SelectCompoundBranchStep
+}
+
+type selectFromHavingBranchStepImpl struct {
+	parent StatementAcceptor
+}
+
+func (s *selectFromHavingBranchStepImpl) Parent() StatementAcceptor {
+	return s.parent
+}
+
+func (s *selectFromHavingBranchStepImpl) Accept(*StatementImpl) error { return nil }
+
+func (s *selectFromHavingBranchStepImpl) Build() Statement {
+	return NewStatementBuilder(s)
+}
+
+func (s *selectFromHavingBranchStepImpl) OrderBy(orders ...*model.SortOrder) SelectFromOrderByBranchStep {
+	return &selectFromOrderByBranchStepImpl{
+		parent: &SelectOrderByStep{
+			parent: s,
+			orders: orders,
+		},
+	}
+}
+
+func (s *selectFromHavingBranchStepImpl) LimitAndOffset(limit int32, offset int64) SelectFromLimitAndOffsetBranchStep {
+	return &selectFromLimitAndOffsetBranchStepImpl{
+		parent: &SelectLimitOffsetStep{
+			parent: s,
+			limit:  limit,
+			offset: offset,
+		},
+	}
+}
+
+func (s *selectFromHavingBranchStepImpl) Union(query model.SubQuery) SelectCompoundBranchStep {
+	return newSelectCompoundBranchStep(s, dialect.SetOperatorUnion, query)
+}
+
+func (s *selectFromHavingBranchStepImpl) UnionAll(query model.SubQuery) SelectCompoundBranchStep {
+	return newSelectCompoundBranchStep(s, dialect.SetOperatorUnionAll, query)
+}
+
+func (s *selectFromHavingBranchStepImpl) Intersect(query model.SubQuery) SelectCompoundBranchStep {
+	return newSelectCompoundBranchStep(s, dialect.SetOperatorIntersect, query)
+}
+
+func (s *selectFromHavingBranchStepImpl) Except(query model.SubQuery) SelectCompoundBranchStep {
+	return newSelectCompoundBranchStep(s, dialect.SetOperatorExcept, query)
+}
+
 type SelectFromOrderByBranchStep interface {
 	Build() Statement
+
+	// SeekAfter fetches the records after lastValues, which are the values of the last record for the orders of OrderBy
+	SeekAfter(lastValues ...interface{}) SelectFromSeekBranchStep
 	LimitAndOffset(limit int32, offset int64) SelectFromLimitAndOffsetBranchStep
 }
 
 type selectFromOrderByBranchStepImpl struct {
-	parent StatementAcceptor
+	parent *SelectOrderByStep
 }
 
 func (s *selectFromOrderByBranchStepImpl) Parent() StatementAcceptor {
@@ -414,6 +578,16 @@ func (s *selectFromOrderByBranchStepImpl) Build() Statement {
 	return NewStatementBuilder(s.parent)
 }
 
+func (s *selectFromOrderByBranchStepImpl) SeekAfter(lastValues ...interface{}) SelectFromSeekBranchStep {
+	return &selectFromSeekBranchStepImpl{
+		parent: &SelectSeekStep{
+			parent: s,
+			orders: s.parent.orders,
+			values: lastValues,
+		},
+	}
+}
+
 func (s *selectFromOrderByBranchStepImpl) LimitAndOffset(limit int32, offset int64) SelectFromLimitAndOffsetBranchStep {
 	return &selectFromLimitAndOffsetBranchStepImpl{
 		parent: &SelectLimitOffsetStep{
@@ -424,6 +598,142 @@ func (s *selectFromOrderByBranchStepImpl) LimitAndOffset(limit int32, offset int
 	}
 }
 
+// SelectFromSeekBranchStep is the step after SeekAfter.
+//
+// SeekAfter adds the condition to fetch the records after lastValues in the orders(keyset pagination).
+// The records are sorted by the orders, or by the orders of OrderBy if SeekAfter follows OrderBy.
+type SelectFromSeekBranchStep interface {
+	Build() Statement
+	LimitAndOffset(limit int32, offset int64) SelectFromLimitAndOffsetBranchStep
+}
+
+type selectFromSeekBranchStepImpl struct {
+	parent StatementAcceptor
+}
+
+func (s *selectFromSeekBranchStepImpl) Parent() StatementAcceptor {
+	return s.parent
+}
+
+func (s *selectFromSeekBranchStepImpl) Accept(*StatementImpl) error { return nil }
+
+func (s *selectFromSeekBranchStepImpl) Build() Statement {
+	return NewStatementBuilder(s)
+}
+
+func (s *selectFromSeekBranchStepImpl) LimitAndOffset(limit int32, offset int64) SelectFromLimitAndOffsetBranchStep {
+	return &selectFromLimitAndOffsetBranchStepImpl{
+		parent: &SelectLimitOffsetStep{
+			parent: s,
+			limit:  limit,
+			offset: offset,
+		},
+	}
+}
+
+// SelectCompoundBranchStep is the step after Union, UnionAll, Intersect and Except.
+//
+// The select statements to be combined must not have ORDER BY and LIMIT.
+// OrderBy sorts the combined result by the column names(or aliases) without the table names.
+type SelectCompoundBranchStep interface {
+	Build() Statement
+	Union(query model.SubQuery) SelectCompoundBranchStep
+	UnionAll(query model.SubQuery) SelectCompoundBranchStep
+	Intersect(query model.SubQuery) SelectCompoundBranchStep
+	Except(query model.SubQuery) SelectCompoundBranchStep
+	OrderBy(orders ...*model.SortOrder) SelectCompoundOrderByBranchStep
+	LimitAndOffset(limit int32, offset int64) SelectFromLimitAndOffsetBranchStep
+}
+
+func newSelectCompoundBranchStep(parent StatementAcceptor, operator string, query model.SubQuery) SelectCompoundBranchStep {
+	return &selectCompoundBranchStepImpl{
+		parent: &SelectCompoundStep{
+			parent:   parent,
+			operator: operator,
+			query:    query,
+		},
+	}
+}
+
+type selectCompoundBranchStepImpl struct {
+	parent StatementAcceptor
+}
+
+func (s *selectCompoundBranchStepImpl) Parent() StatementAcceptor {
+	return s.parent
+}
+
+func (s *selectCompoundBranchStepImpl) Accept(*StatementImpl) error { return nil }
+
+func (s *selectCompoundBranchStepImpl) Build() Statement {
+	return NewStatementBuilder(s)
+}
+
+func (s *selectCompoundBranchStepImpl) Union(query model.SubQuery) SelectCompoundBranchStep {
+	return newSelectCompoundBranchStep(s, dialect.SetOperatorUnion, query)
+}
+
+func (s *selectCompoundBranchStepImpl) UnionAll(query model.SubQuery) SelectCompoundBranchStep {
+	return newSelectCompoundBranchStep(s, dialect.SetOperatorUnionAll, query)
+}
+
+func (s *selectCompoundBranchStepImpl) Intersect(query model.SubQuery) SelectCompoundBranchStep {
+	return newSelectCompoundBranchStep(s, dialect.SetOperatorIntersect, query)
+}
+
+func (s *selectCompoundBranchStepImpl) Except(query model.SubQuery) SelectCompoundBranchStep {
+	return newSelectCompoundBranchStep(s, dialect.SetOperatorExcept, query)
+}
+
+func (s *selectCompoundBranchStepImpl) OrderBy(orders ...*model.SortOrder) SelectCompoundOrderByBranchStep {
+	return &selectCompoundOrderByBranchStepImpl{
+		parent: &SelectOrderByStep{
+			parent:   s,
+			orders:   orders,
+			compound: true,
+		},
+	}
+}
+
+func (s *selectCompoundBranchStepImpl) LimitAndOffset(limit int32, offset int64) SelectFromLimitAndOffsetBranchStep {
+	return &selectFromLimitAndOffsetBranchStepImpl{
+		parent: &SelectLimitOffsetStep{
+			parent: s,
+			limit:  limit,
+			offset: offset,
+		},
+	}
+}
+
+type SelectCompoundOrderByBranchStep interface {
+	Build() Statement
+	LimitAndOffset(limit int32, offset int64) SelectFromLimitAndOffsetBranchStep
+}
+
+type selectCompoundOrderByBranchStepImpl struct {
+	parent StatementAcceptor
+}
+
+func (s *selectCompoundOrderByBranchStepImpl) Parent() StatementAcceptor {
+	return s.parent
+}
+
+func (s *selectCompoundOrderByBranchStepImpl) Accept(*StatementImpl) error { return nil }
+
+func (s *selectCompoundOrderByBranchStepImpl) Build() Statement {
+	return NewStatementBuilder(s)
+}
+
+func (s *selectCompoundOrderByBranchStepImpl) LimitAndOffset(limit int32, offset int64) SelectFromLimitAndOffsetBranchStep {
+	return &selectFromLimitAndOffsetBranchStepImpl{
+		parent: &SelectLimitOffsetStep{
+			parent: s,
+			limit:  limit,
+			offset: offset,
+		},
+	}
+}
+
 type SelectFromLimitAndOffsetBranchStep interface {
 	Build() Statement
 }
@@ -4,7 +4,10 @@ import "github.com/tmarcus87/sqlike/model"
 
 type UpdateBranchStep interface {
 	SetValue(columnValue model.ColumnValue) UpdateSetBranchStep
+	SetExpr(column model.Column, expr model.Expression) UpdateSetBranchStep
 	SetRecord(record *model.Record) UpdateSetRecordBranchStep
+	InnerJoin(table model.Table, conditions ...model.Condition) UpdateJoinBranchStep
+	LeftOuterJoin(table model.Table, conditions ...model.Condition) UpdateJoinBranchStep
 }
 
 func NewUpdateBranchStep(parent StatementAcceptor, table model.Table) UpdateBranchStep {
@@ -35,6 +38,10 @@ func (s *updateBranchStepImpl) SetValue(columnValue model.ColumnValue) UpdateSet
 	}
 }
 
+func (s *updateBranchStepImpl) SetExpr(column model.Column, expr model.Expression) UpdateSetBranchStep {
+	return s.SetValue(model.ExprValue(column, expr))
+}
+
 func (s *updateBranchStepImpl) SetRecord(record *model.Record) UpdateSetRecordBranchStep {
 	return &updateSetRecordBranchStepImpl{
 		parent: &UpdateSetRecordStep{
@@ -44,9 +51,22 @@ func (s *updateBranchStepImpl) SetRecord(record *model.Record) UpdateSetRecordBr
 	}
 }
 
+func (s *updateBranchStepImpl) InnerJoin(table model.Table, conditions ...model.Condition) UpdateJoinBranchStep {
+	return newUpdateJoinBranchStep(s, table, conditions, "INNER JOIN")
+}
+
+func (s *updateBranchStepImpl) LeftOuterJoin(table model.Table, conditions ...model.Condition) UpdateJoinBranchStep {
+	return newUpdateJoinBranchStep(s, table, conditions, "LEFT OUTER JOIN")
+}
+
 type UpdateSetBranchStep interface {
 	SetValue(columnValue model.ColumnValue) UpdateSetBranchStep
+	SetExpr(column model.Column, expr model.Expression) UpdateSetBranchStep
 	Where(conditions ...model.Condition) UpdateWhereBranchStep
+	AllRows() UpdateWhereBranchStep
+	OrderBy(orders ...*model.SortOrder) WriteOrderByBranchStep
+	Limit(limit int32) WriteLimitBranchStep
+	Returning(columns ...model.ColumnField) ReturningBranchStep
 	Build() Statement
 }
 
@@ -69,6 +89,10 @@ func (s *updateSetBranchStepImpl) SetValue(columnValue model.ColumnValue) Update
 	}
 }
 
+func (s *updateSetBranchStepImpl) SetExpr(column model.Column, expr model.Expression) UpdateSetBranchStep {
+	return s.SetValue(model.ExprValue(column, expr))
+}
+
 func (s *updateSetBranchStepImpl) Where(conditions ...model.Condition) UpdateWhereBranchStep {
 	return &updateWhereBranchStepImpl{
 		parent: &WhereStep{
@@ -78,12 +102,38 @@ func (s *updateSetBranchStepImpl) Where(conditions ...model.Condition) UpdateWhe
 	}
 }
 
+func (s *updateSetBranchStepImpl) AllRows() UpdateWhereBranchStep {
+	return &updateWhereBranchStepImpl{
+		parent: &AllRowsStep{
+			parent: s,
+		},
+	}
+}
+
+func (s *updateSetBranchStepImpl) OrderBy(orders ...*model.SortOrder) WriteOrderByBranchStep {
+	return &writeOrderByBranchStepImpl{
+		parent: &WriteOrderByStep{
+			parent: s,
+			orders: orders,
+		},
+	}
+}
+
+func (s *updateSetBranchStepImpl) Limit(limit int32) WriteLimitBranchStep {
+	return newWriteLimitBranchStep(s, limit)
+}
+
 func (s *updateSetBranchStepImpl) Build() Statement {
 	return NewStatementBuilder(s)
 }
 
+func (s *updateSetBranchStepImpl) Returning(columns ...model.ColumnField) ReturningBranchStep {
+	return newReturningBranchStep(s, columns)
+}
+
 type UpdateSetRecordBranchStep interface {
 	Where(conditions ...model.Condition) UpdateWhereBranchStep
+	AllRows() UpdateWhereBranchStep
 }
 
 type updateSetRecordBranchStepImpl struct {
@@ -105,7 +155,18 @@ func (s *updateSetRecordBranchStepImpl) Where(conditions ...model.Condition) Upd
 	}
 }
 
+func (s *updateSetRecordBranchStepImpl) AllRows() UpdateWhereBranchStep {
+	return &updateWhereBranchStepImpl{
+		parent: &AllRowsStep{
+			parent: s,
+		},
+	}
+}
+
 type UpdateWhereBranchStep interface {
+	OrderBy(orders ...*model.SortOrder) WriteOrderByBranchStep
+	Limit(limit int32) WriteLimitBranchStep
+	Returning(columns ...model.ColumnField) ReturningBranchStep
 	Build() Statement
 }
 
@@ -122,3 +183,140 @@ func (s *updateWhereBranchStepImpl) Accept(*StatementImpl) error { return nil }
 func (s *updateWhereBranchStepImpl) Build() Statement {
 	return NewStatementBuilder(s)
 }
+
+func (s *updateWhereBranchStepImpl) Returning(columns ...model.ColumnField) ReturningBranchStep {
+	return newReturningBranchStep(s, columns)
+}
+
+func (s *updateWhereBranchStepImpl) OrderBy(orders ...*model.SortOrder) WriteOrderByBranchStep {
+	return &writeOrderByBranchStepImpl{
+		parent: &WriteOrderByStep{
+			parent: s,
+			orders: orders,
+		},
+	}
+}
+
+func (s *updateWhereBranchStepImpl) Limit(limit int32) WriteLimitBranchStep {
+	return newWriteLimitBranchStep(s, limit)
+}
+
+// UpdateJoinBranchStep is the multi table UPDATE such as `UPDATE a INNER JOIN b ON ... SET ...`, which is available in MySQL
+type UpdateJoinBranchStep interface {
+	InnerJoin(table model.Table, conditions ...model.Condition) UpdateJoinBranchStep
+	LeftOuterJoin(table model.Table, conditions ...model.Condition) UpdateJoinBranchStep
+	SetValue(columnValue model.ColumnValue) UpdateJoinSetBranchStep
+	SetExpr(column model.Column, expr model.Expression) UpdateJoinSetBranchStep
+}
+
+func newUpdateJoinBranchStep(parent StatementAcceptor, table model.Table, conditions []model.Condition, joinType string) UpdateJoinBranchStep {
+	return &updateJoinBranchStepImpl{
+		parent: &WriteJoinStep{
+			parent:     parent,
+			table:      table,
+			conditions: conditions,
+			joinType:   joinType,
+		},
+	}
+}
+
+type updateJoinBranchStepImpl struct {
+	parent StatementAcceptor
+}
+
+func (s *updateJoinBranchStepImpl) Parent() StatementAcceptor {
+	return s.parent
+}
+
+func (s *updateJoinBranchStepImpl) Accept(*StatementImpl) error { return nil }
+
+func (s *updateJoinBranchStepImpl) InnerJoin(table model.Table, conditions ...model.Condition) UpdateJoinBranchStep {
+	return newUpdateJoinBranchStep(s, table, conditions, "INNER JOIN")
+}
+
+func (s *updateJoinBranchStepImpl) LeftOuterJoin(table model.Table, conditions ...model.Condition) UpdateJoinBranchStep {
+	return newUpdateJoinBranchStep(s, table, conditions, "LEFT OUTER JOIN")
+}
+
+func (s *updateJoinBranchStepImpl) SetValue(columnValue model.ColumnValue) UpdateJoinSetBranchStep {
+	return &updateJoinSetBranchStepImpl{
+		parent: &UpdateSetStep{
+			parent:      s,
+			columnValue: columnValue,
+		},
+	}
+}
+
+func (s *updateJoinBranchStepImpl) SetExpr(column model.Column, expr model.Expression) UpdateJoinSetBranchStep {
+	return s.SetValue(model.ExprValue(column, expr))
+}
+
+type UpdateJoinSetBranchStep interface {
+	SetValue(columnValue model.ColumnValue) UpdateJoinSetBranchStep
+	SetExpr(column model.Column, expr model.Expression) UpdateJoinSetBranchStep
+	Where(conditions ...model.Condition) UpdateJoinWhereBranchStep
+	AllRows() UpdateJoinWhereBranchStep
+	Build() Statement
+}
+
+type updateJoinSetBranchStepImpl struct {
+	parent StatementAcceptor
+}
+
+func (s *updateJoinSetBranchStepImpl) Parent() StatementAcceptor {
+	return s.parent
+}
+
+func (s *updateJoinSetBranchStepImpl) Accept(*StatementImpl) error { return nil }
+
+func (s *updateJoinSetBranchStepImpl) SetValue(columnValue model.ColumnValue) UpdateJoinSetBranchStep {
+	return &updateJoinSetBranchStepImpl{
+		parent: &UpdateSetStep{
+			parent:      s,
+			columnValue: columnValue,
+		},
+	}
+}
+
+func (s *updateJoinSetBranchStepImpl) SetExpr(column model.Column, expr model.Expression) UpdateJoinSetBranchStep {
+	return s.SetValue(model.ExprValue(column, expr))
+}
+
+func (s *updateJoinSetBranchStepImpl) Where(conditions ...model.Condition) UpdateJoinWhereBranchStep {
+	return &updateJoinWhereBranchStepImpl{
+		parent: &WhereStep{
+			parent:     s,
+			conditions: conditions,
+		},
+	}
+}
+
+func (s *updateJoinSetBranchStepImpl) AllRows() UpdateJoinWhereBranchStep {
+	return &updateJoinWhereBranchStepImpl{
+		parent: &AllRowsStep{
+			parent: s,
+		},
+	}
+}
+
+func (s *updateJoinSetBranchStepImpl) Build() Statement {
+	return NewStatementBuilder(s)
+}
+
+type UpdateJoinWhereBranchStep interface {
+	Build() Statement
+}
+
+type updateJoinWhereBranchStepImpl struct {
+	parent StatementAcceptor
+}
+
+func (s *updateJoinWhereBranchStepImpl) Parent() StatementAcceptor {
+	return s.parent
+}
+
+func (s *updateJoinWhereBranchStepImpl) Accept(*StatementImpl) error { return nil }
+
+func (s *updateJoinWhereBranchStepImpl) Build() Statement {
+	return NewStatementBuilder(s)
+}
@@ -0,0 +1,116 @@
+package statement
+
+import "github.com/tmarcus87/sqlike/model"
+
+// UpsertBranchStep inserts the records and updates the rows which conflict with the keys,
+// such as `INSERT ... ON DUPLICATE KEY UPDATE c = VALUES(c)` in MySQL
+// and `INSERT ... ON CONFLICT (k) DO UPDATE SET c = excluded.c` in PostgreSQL and SQLite.
+type UpsertBranchStep interface {
+	Records(records ...*model.Record) UpsertRecordsBranchStep
+}
+
+func NewUpsertBranchStep(parent StatementAcceptor, table model.Table) UpsertBranchStep {
+	return &upsertBranchStepImpl{
+		parent: &InsertIntoStep{
+			parent: parent,
+			table:  table,
+		},
+	}
+}
+
+type upsertBranchStepImpl struct {
+	parent StatementAcceptor
+}
+
+func (s *upsertBranchStepImpl) Records(records ...*model.Record) UpsertRecordsBranchStep {
+	return &upsertRecordsBranchStepImpl{
+		parent:  s.parent,
+		records: records,
+	}
+}
+
+type UpsertRecordsBranchStep interface {
+	// OnConflict specifies the keys(conflict target), which are ignored in MySQL
+	OnConflict(keys ...model.Column) UpsertConflictBranchStep
+}
+
+type upsertRecordsBranchStepImpl struct {
+	parent  StatementAcceptor
+	records []*model.Record
+}
+
+func (s *upsertRecordsBranchStepImpl) OnConflict(keys ...model.Column) UpsertConflictBranchStep {
+	return &upsertConflictBranchStepImpl{
+		parent:  s.parent,
+		records: s.records,
+		keys:    keys,
+	}
+}
+
+type UpsertConflictBranchStep interface {
+	// Update updates the columns by the values proposed for insertion
+	Update(columns ...model.Column) UpsertUpdateBranchStep
+
+	// UpdateAllExcept updates all inserted columns except the keys and the columns
+	UpdateAllExcept(columns ...model.Column) UpsertUpdateBranchStep
+}
+
+type upsertConflictBranchStepImpl struct {
+	parent  StatementAcceptor
+	records []*model.Record
+	keys    []model.Column
+}
+
+func (s *upsertConflictBranchStepImpl) Update(columns ...model.Column) UpsertUpdateBranchStep {
+	return &upsertUpdateBranchStepImpl{
+		parent: &UpsertStep{
+			parent:  s.parent,
+			records: s.records,
+			keys:    s.keys,
+			columns: columns,
+		},
+	}
+}
+
+func (s *upsertConflictBranchStepImpl) UpdateAllExcept(columns ...model.Column) UpsertUpdateBranchStep {
+	return &upsertUpdateBranchStepImpl{
+		parent: &UpsertStep{
+			parent:        s.parent,
+			records:       s.records,
+			keys:          s.keys,
+			columns:       columns,
+			exceptColumns: true,
+		},
+	}
+}
+
+type UpsertUpdateBranchStep interface {
+	Returning(columns ...model.ColumnField) ReturningBranchStep
+	Chunked(size int) InsertChunkedBranchStep
+	Build() Statement
+}
+
+type upsertUpdateBranchStepImpl struct {
+	parent *UpsertStep
+}
+
+func (s *upsertUpdateBranchStepImpl) Parent() StatementAcceptor {
+	return s.parent
+}
+
+func (s *upsertUpdateBranchStepImpl) Accept(*StatementImpl) error { return nil }
+
+func (s *upsertUpdateBranchStepImpl) Returning(columns ...model.ColumnField) ReturningBranchStep {
+	return newReturningBranchStep(s, columns)
+}
+
+func (s *upsertUpdateBranchStepImpl) Chunked(size int) InsertChunkedBranchStep {
+	return &insertChunkedBranchStepImpl{
+		target: s.parent,
+		size:   size,
+	}
+}
+
+func (s *upsertUpdateBranchStepImpl) Build() Statement {
+	return NewStatementBuilder(s)
+}
@@ -0,0 +1,74 @@
+package statement
+
+import "github.com/tmarcus87/sqlike/model"
+
+// WithBranchStep defines the common table expressions, which are available in the following statement.
+//
+// The recursive common table expression refers itself by the table which has the same name
+// such as model.NewTable(name).
+type WithBranchStep interface {
+	// Table returns the table of the last defined common table expression
+	Table() model.Table
+	With(name string, query model.SubQuery, columns ...string) WithBranchStep
+	WithRecursive(name string, query model.SubQuery, columns ...string) WithBranchStep
+	Select(columns ...model.ColumnField) SelectColumnBranchStep
+	SelectFrom(table model.Table) SelectFromBranchStep
+	Update(table model.Table) UpdateBranchStep
+	DeleteFrom(table model.Table) DeleteFromBranchStep
+}
+
+func NewWithBranchStep(parent StatementAcceptor, name string, query model.SubQuery, columns ...string) WithBranchStep {
+	return newWithBranchStep(parent, nil, &commonTableExpr{name: name, columns: columns, query: query})
+}
+
+func NewWithRecursiveBranchStep(parent StatementAcceptor, name string, query model.SubQuery, columns ...string) WithBranchStep {
+	return newWithBranchStep(parent, nil, &commonTableExpr{name: name, columns: columns, query: query, recursive: true})
+}
+
+// newWithBranchStep 共通テーブル式はひとつのWITH句にまとめて出力する
+func newWithBranchStep(parent StatementAcceptor, ctes []*commonTableExpr, cte *commonTableExpr) WithBranchStep {
+	return &withBranchStepImpl{
+		parent: &WithStep{
+			parent: parent,
+			ctes:   append(append(make([]*commonTableExpr, 0, len(ctes)+1), ctes...), cte),
+		},
+	}
+}
+
+type withBranchStepImpl struct {
+	parent *WithStep
+}
+
+func (s *withBranchStepImpl) Parent() StatementAcceptor {
+	return s.parent
+}
+
+func (s *withBranchStepImpl) Accept(*StatementImpl) error { return nil }
+
+func (s *withBranchStepImpl) Table() model.Table {
+	return model.NewTable(s.parent.ctes[len(s.parent.ctes)-1].name)
+}
+
+func (s *withBranchStepImpl) With(name string, query model.SubQuery, columns ...string) WithBranchStep {
+	return newWithBranchStep(s.parent.parent, s.parent.ctes, &commonTableExpr{name: name, columns: columns, query: query})
+}
+
+func (s *withBranchStepImpl) WithRecursive(name string, query model.SubQuery, columns ...string) WithBranchStep {
+	return newWithBranchStep(s.parent.parent, s.parent.ctes, &commonTableExpr{name: name, columns: columns, query: query, recursive: true})
+}
+
+func (s *withBranchStepImpl) Select(columns ...model.ColumnField) SelectColumnBranchStep {
+	return NewSelectColumnBranchStep(s, columns...)
+}
+
+func (s *withBranchStepImpl) SelectFrom(table model.Table) SelectFromBranchStep {
+	return NewSelectFromBranchStep(s, table)
+}
+
+func (s *withBranchStepImpl) Update(table model.Table) UpdateBranchStep {
+	return NewUpdateBranchStep(s, table)
+}
+
+func (s *withBranchStepImpl) DeleteFrom(table model.Table) DeleteFromBranchStep {
+	return NewDeleteFromBranchStep(s, table)
+}
@@ -0,0 +1,85 @@
+package statement
+
+import "github.com/tmarcus87/sqlike/model"
+
+// WriteOrderByBranchStep is ORDER BY of the single table UPDATE and DELETE, which is available in MySQL
+type WriteOrderByBranchStep interface {
+	Limit(limit int32) WriteLimitBranchStep
+	Build() Statement
+}
+
+type writeOrderByBranchStepImpl struct {
+	parent StatementAcceptor
+}
+
+func (s *writeOrderByBranchStepImpl) Parent() StatementAcceptor {
+	return s.parent
+}
+
+func (s *writeOrderByBranchStepImpl) Accept(*StatementImpl) error { return nil }
+
+func (s *writeOrderByBranchStepImpl) Limit(limit int32) WriteLimitBranchStep {
+	return newWriteLimitBranchStep(s, limit)
+}
+
+func (s *writeOrderByBranchStepImpl) Build() Statement {
+	return NewStatementBuilder(s)
+}
+
+// WriteLimitBranchStep is LIMIT of the single table UPDATE and DELETE, which is available in MySQL
+type WriteLimitBranchStep interface {
+	Build() Statement
+}
+
+func newWriteLimitBranchStep(parent StatementAcceptor, limit int32) WriteLimitBranchStep {
+	return &writeLimitBranchStepImpl{
+		parent: &WriteLimitStep{
+			parent: parent,
+			limit:  limit,
+		},
+	}
+}
+
+type writeLimitBranchStepImpl struct {
+	parent StatementAcceptor
+}
+
+func (s *writeLimitBranchStepImpl) Parent() StatementAcceptor {
+	return s.parent
+}
+
+func (s *writeLimitBranchStepImpl) Accept(*StatementImpl) error { return nil }
+
+func (s *writeLimitBranchStepImpl) Build() Statement {
+	return NewStatementBuilder(s)
+}
+
+// ReturningBranchStep is RETURNING of INSERT, UPDATE and DELETE, which is available in PostgreSQL and SQLite.
+// The statement returns the rows by FetchInto and so on, and each call of them writes the rows again.
+// Execute returns ErrorReturningExecute since it would discard the returned rows.
+type ReturningBranchStep interface {
+	Build() Statement
+}
+
+func newReturningBranchStep(parent StatementAcceptor, columns []model.ColumnField) ReturningBranchStep {
+	return &returningBranchStepImpl{
+		parent: &ReturningStep{
+			parent:  parent,
+			columns: columns,
+		},
+	}
+}
+
+type returningBranchStepImpl struct {
+	parent StatementAcceptor
+}
+
+func (s *returningBranchStepImpl) Parent() StatementAcceptor {
+	return s.parent
+}
+
+func (s *returningBranchStepImpl) Accept(*StatementImpl) error { return nil }
+
+func (s *returningBranchStepImpl) Build() Statement {
+	return NewStatementBuilder(s)
+}
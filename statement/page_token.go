@@ -0,0 +1,45 @@
+package statement
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrorInvalidPageToken = errors.New("invalid page token")
+)
+
+// EncodePageToken encodes the values of the last record in the page to the opaque token,
+// which can be decoded by DecodePageToken and passed to SeekAfter for the next page.
+func EncodePageToken(values ...interface{}) (string, error) {
+	b, err := json.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode page token : %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodePageToken decodes the token encoded by EncodePageToken into dest(pointers to the values).
+func DecodePageToken(token string, dest ...interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return fmt.Errorf("%w : %v", ErrorInvalidPageToken, err)
+	}
+
+	values := make([]json.RawMessage, 0)
+	if err := json.Unmarshal(b, &values); err != nil {
+		return fmt.Errorf("%w : %v", ErrorInvalidPageToken, err)
+	}
+	if len(values) != len(dest) {
+		return fmt.Errorf("%w : expected %d values but %d", ErrorInvalidPageToken, len(dest), len(values))
+	}
+
+	for i, v := range values {
+		if err := json.Unmarshal(v, dest[i]); err != nil {
+			return fmt.Errorf("%w : %v", ErrorInvalidPageToken, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package statement
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestPageToken(t *testing.T) {
+	t.Run("EncodeAndDecode", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		createdAt := time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC)
+
+		token, err := EncodePageToken("foo", int64(10), createdAt)
+		asserts.Nil(err)
+
+		var (
+			name string
+			id   int64
+			at   time.Time
+		)
+		asserts.Nil(DecodePageToken(token, &name, &id, &at))
+		asserts.Equal("foo", name)
+		asserts.Equal(int64(10), id)
+		asserts.True(createdAt.Equal(at))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		var id int64
+		err := DecodePageToken("!invalid!", &id)
+		assert.True(t, errors.Is(err, ErrorInvalidPageToken))
+	})
+
+	t.Run("NumberOfValuesMismatch", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		token, err := EncodePageToken("foo", int64(10))
+		asserts.Nil(err)
+
+		var id int64
+		err = DecodePageToken(token, &id)
+		asserts.True(errors.Is(err, ErrorInvalidPageToken))
+	})
+}
@@ -1,50 +1,310 @@
 package statement
 
 import (
+	"database/sql"
+	"database/sql/driver"
 	"errors"
+	"fmt"
 	"reflect"
 	"strings"
+	"sync"
+	"time"
 )
 
 var (
-	ErrorMustBeAPointer   = errors.New("must be a pointer")
-	ErrorMustBeANonNilPtr = errors.New("must be a non-nil pointer")
+	ErrorMustBeAPointer    = errors.New("must be a pointer")
+	ErrorMustBeANonNilPtr  = errors.New("must be a non-nil pointer")
+	ErrorAmbiguousColumn   = errors.New("column is ambiguous")
+	ErrorNilEmbeddedStruct = errors.New("embedded struct is nil")
 )
 
-func getOrderedColumnName(value interface{}) ([]string, error) {
-	v := reflect.ValueOf(value)
-	if v.Kind() == reflect.Ptr {
-		v = v.Elem()
+// structMeta 構造体のフィールドとカラムの対応で、型ごとにキャッシュする
+type structMeta struct {
+	// fields 構造体のフィールド順で、同名のカラムは優先されるフィールドのみ
+	fields []*fieldMeta
+	byName map[string]*fieldMeta
+	// ambiguous 優先されるフィールドと同じ深さに同名のフィールドがあり、対応を決められないカラム
+	ambiguous map[string]struct{}
+}
+
+// fieldMeta カラムに対応するフィールド
+type fieldMeta struct {
+	name  string
+	index []int
+	depth int
+	// prefixed 入れ子の構造体のフィールドで、カラム名に接頭辞がついている
+	prefixed bool
+}
+
+var (
+	structMetaCache sync.Map
+
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	valuerType  = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	timeType    = reflect.TypeOf(time.Time{})
+)
+
+// getStructMeta 型のメタデータをキャッシュから取得する。並行に呼ばれても同じメタデータを返す
+func getStructMeta(t reflect.Type) *structMeta {
+	if m, ok := structMetaCache.Load(t); ok {
+		return m.(*structMeta)
+	}
+	m, _ := structMetaCache.LoadOrStore(t, newStructMeta(t))
+	return m.(*structMeta)
+}
+
+func newStructMeta(t reflect.Type) *structMeta {
+	all := make([]*fieldMeta, 0)
+	collectFields(t, nil, "", nil, &all)
+
+	m := &structMeta{
+		fields:    make([]*fieldMeta, 0),
+		byName:    make(map[string]*fieldMeta),
+		ambiguous: make(map[string]struct{}),
+	}
+	// 埋め込みのフィールドはGoと同様に浅いものを優先し、同じ深さに同名のフィールドがある場合は曖昧とする
+	for _, fm := range all {
+		if w, ok := m.byName[fm.name]; !ok || fm.depth < w.depth {
+			m.byName[fm.name] = fm
+		}
+	}
+	for _, fm := range all {
+		if w := m.byName[fm.name]; w == fm {
+			m.fields = append(m.fields, fm)
+		} else if fm.depth == w.depth {
+			m.ambiguous[fm.name] = struct{}{}
+		}
+	}
+	return m
+}
+
+// field カラムに対応するフィールドを返す。対応するフィールドがない場合はnil
+func (m *structMeta) field(name string) (*fieldMeta, error) {
+	if _, ok := m.ambiguous[name]; ok {
+		return nil, fmt.Errorf("%w : '%s' is mapped to several fields, use the prefix option of the nested struct", ErrorAmbiguousColumn, name)
 	}
+	return m.byName[name], nil
+}
+
+// collectFields 埋め込みの構造体と入れ子の構造体を展開してフィールドを集める。
+// 入れ子の構造体のカラム名には `sqlike:",prefix=author_"` で指定した接頭辞をつけ、指定がない場合は `author_` のようにフィールド名を接頭辞とする。
+// parents は循環するポインタの埋め込みを展開しないための、展開中の構造体の型
+func collectFields(t reflect.Type, index []int, prefix string, parents []reflect.Type, all *[]*fieldMeta) {
+	parents = append(parents[:len(parents):len(parents)], t)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		fieldIndex := append(append(make([]int, 0, len(index)+1), index...), i)
+
+		name, options := parseTag(f)
+		if isNestedStruct(f) {
+			nestedPrefix := tagOption(options, "prefix")
+			if nestedPrefix == "" && !f.Anonymous {
+				nestedPrefix = name + "_"
+			}
+			collectFields(f.Type, fieldIndex, prefix+nestedPrefix, parents, all)
+			continue
+		}
+		if isEmbeddedStructPtr(f) {
+			// 非公開の型のポインタは割り当てられず、展開中の型のポインタは循環するため展開しない
+			if f.PkgPath == "" && !containsType(parents, f.Type.Elem()) {
+				collectFields(f.Type.Elem(), fieldIndex, prefix, parents, all)
+			}
+			continue
+		}
+
+		// 非公開のフィールドは値を取得できない
+		if f.PkgPath != "" {
+			continue
+		}
+
+		*all = append(*all, &fieldMeta{
+			name:     prefix + name,
+			index:    fieldIndex,
+			depth:    len(index),
+			prefixed: prefix != "",
+		})
+	}
+}
+
+// isNestedStruct カラム名の指定がなく、値として扱えない構造体のフィールドは展開する
+func isNestedStruct(f reflect.StructField) bool {
+	t := f.Type
+	if t.Kind() != reflect.Struct || t == timeType {
+		return false
+	}
+	if reflect.PtrTo(t).Implements(scannerType) || t.Implements(valuerType) {
+		return false
+	}
+	if name := strings.Split(f.Tag.Get("sqlike"), ",")[0]; name != "" {
+		return false
+	}
+	// 非公開の型の埋め込みでも、公開されたフィールドは値を取得できる
+	return f.PkgPath == "" || f.Anonymous
+}
 
-	res := make([]string, 0)
-	for i := 0; i < v.Type().NumField(); i++ {
-		f := v.Type().Field(i)
-		res = append(res, getColumnName(f))
+// isEmbeddedStructPtr `*BaseEntity` のような構造体のポインタの埋め込みは展開する
+func isEmbeddedStructPtr(f reflect.StructField) bool {
+	if !f.Anonymous || f.Type.Kind() != reflect.Ptr {
+		return false
+	}
+	t := f.Type.Elem()
+	if t.Kind() != reflect.Struct || t == timeType {
+		return false
+	}
+	if f.Type.Implements(scannerType) || t.Implements(valuerType) || f.Type.Implements(valuerType) {
+		return false
+	}
+	return strings.Split(f.Tag.Get("sqlike"), ",")[0] == ""
+}
+
+func containsType(types []reflect.Type, t reflect.Type) bool {
+	for _, tt := range types {
+		if tt == t {
+			return true
+		}
+	}
+	return false
+}
+
+// tagOption `key=value` 形式のオプションの値を返す
+func tagOption(options []string, key string) string {
+	for _, option := range options {
+		if strings.HasPrefix(option, key+"=") {
+			return strings.TrimPrefix(option, key+"=")
+		}
+	}
+	return ""
+}
+
+// indexes カラムに対応するフィールドのインデックスを返す。対応するフィールドがない場合はnil。
+// JOINの結果などで同名のカラムが複数ある場合、接頭辞のないフィールドは従来通り後のカラムの値で上書きし、
+// 入れ子の構造体のフィールドはどのカラムに対応するか決められないためエラーとする
+func (m *structMeta) indexes(names []string) ([][]int, error) {
+	res := make([][]int, len(names))
+	seen := make(map[string]struct{})
+	for i, name := range names {
+		f, err := m.field(name)
+		if err != nil {
+			return nil, err
+		}
+		if f == nil {
+			continue
+		}
+		if _, ok := seen[name]; ok && f.prefixed {
+			return nil, fmt.Errorf("%w : '%s' appears more than once in the result, alias it by As", ErrorAmbiguousColumn, name)
+		}
+		seen[name] = struct{}{}
+		res[i] = f.index
 	}
 	return res, nil
 }
 
-func getColumnName2FieldValueMap(value interface{}) (map[string]reflect.Value, error) {
-	v := reflect.ValueOf(value)
-	if v.Kind() == reflect.Ptr {
-		v = v.Elem()
+// structValue 構造体(もしくはそのポインタ)の値とメタデータを返す
+func structValue(value interface{}) (reflect.Value, *structMeta, error) {
+	v := reflect.Indirect(reflect.ValueOf(value))
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, nil, fmt.Errorf("%T : %w", value, ErrorMustBeAStructPtr)
 	}
+	return v, getStructMeta(v.Type()), nil
+}
 
-	name2value := make(map[string]reflect.Value)
-	for i := 0; i < v.Type().NumField(); i++ {
-		f := v.Type().Field(i)
-		name2value[getColumnName(f)] = v.Field(i)
+// fieldPtrs インデックスのフィールドのポインタを返す。インデックスがnilの場合は読み飛ばす
+func fieldPtrs(v reflect.Value, indexes [][]int) []interface{} {
+	vptrs := make([]interface{}, len(indexes))
+	for i, index := range indexes {
+		if index == nil {
+			vptrs[i] = DummyScanner{}
+			continue
+		}
+		vptrs[i] = allocFieldByIndex(v, index).Addr().Interface()
 	}
+	return vptrs
+}
 
-	return name2value, nil
+// allocFieldByIndex インデックスのフィールドを返す。埋め込みのポインタがnilの場合は割り当てる
+func allocFieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
 }
 
-func getColumnName(f reflect.StructField) string {
-	if tag, ok := f.Tag.Lookup("sqlike"); ok {
-		return tag
+// fieldByIndex インデックスのフィールドを返す。埋め込みのポインタがnilの場合はfalseを返す
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// getOrderedColumnName 構造体のフィールド順にカラム名を返す。nilの埋め込みのポインタのカラムは含めない
+func getOrderedColumnName(value interface{}) ([]string, error) {
+	v, m, err := structValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]string, 0, len(m.fields))
+	for _, f := range m.fields {
+		if _, err := m.field(f.name); err != nil {
+			return nil, err
+		}
+		if _, ok := fieldByIndex(v, f.index); ok {
+			res = append(res, f.name)
+		}
+	}
+	return res, nil
+}
+
+// fieldValues カラムに対応するフィールドの値を返す。レコードごとにmapを作らず、キャッシュしたメタデータから引く
+func fieldValues(value interface{}, columns []string) ([]interface{}, error) {
+	v, m, err := structValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, 0, len(columns))
+	for _, column := range columns {
+		f, err := m.field(column)
+		if err != nil {
+			return nil, err
+		}
+		if f == nil {
+			return nil, fmt.Errorf("struct field for '%s' is not found", column)
+		}
+		fv, ok := fieldByIndex(v, f.index)
+		if !ok {
+			return nil, fmt.Errorf("%w : struct field for '%s' is in the nil embedded struct", ErrorNilEmbeddedStruct, column)
+		}
+		values = append(values, fv.Interface())
+	}
+	return values, nil
+}
+
+// parseTag `sqlike:"name,option..."` を解析する。名前がない場合はフィールド名をスネークケースにする
+func parseTag(f reflect.StructField) (string, []string) {
+	tag, ok := f.Tag.Lookup("sqlike")
+	if !ok {
+		return toSnakeCase(f.Name), nil
+	}
+	parts := strings.Split(tag, ",")
+	if parts[0] == "" {
+		parts[0] = toSnakeCase(f.Name)
 	}
-	return toSnakeCase(f.Name)
+	return parts[0], parts[1:]
 }
 
 func toSnakeCase(s string) string {
@@ -1,11 +1,18 @@
 package statement
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/tmarcus87/sqlike/dialect"
+	"github.com/tmarcus87/sqlike/model"
+	"reflect"
 	"testing"
+	"time"
 )
 
-func TestGetFieldValueMap(t *testing.T) {
+func TestFieldValues(t *testing.T) {
 	type Value struct {
 		Key1 string
 		Key2 int `sqlike:"key2alt"`
@@ -17,26 +24,255 @@ func TestGetFieldValueMap(t *testing.T) {
 	}
 
 	t.Run("WithNonPtr", func(t *testing.T) {
-		fvm, err := getColumnName2FieldValueMap(v)
+		values, err := fieldValues(v, []string{"key2alt", "key1"})
 
 		asserts := assert.New(t)
 		asserts.Nil(err)
-		asserts.Contains(fvm, "key1")
-		asserts.NotContains(fvm, "key2")
-		asserts.Contains(fvm, "key2alt")
-		asserts.Equal("key1", fvm["key1"].Interface())
-		asserts.Equal(2, fvm["key2alt"].Interface())
+		asserts.Equal([]interface{}{2, "key1"}, values)
 	})
 
 	t.Run("WithPtr", func(t *testing.T) {
-		fvm, err := getColumnName2FieldValueMap(&v)
+		values, err := fieldValues(&v, []string{"key1", "key2alt"})
 
 		asserts := assert.New(t)
 		asserts.Nil(err)
-		asserts.Contains(fvm, "key1")
-		asserts.NotContains(fvm, "key2")
-		asserts.Contains(fvm, "key2alt")
-		asserts.Equal("key1", fvm["key1"].Interface())
-		asserts.Equal(2, fvm["key2alt"].Interface())
+		asserts.Equal([]interface{}{"key1", 2}, values)
 	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := fieldValues(&v, []string{"key2"})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestGetStructMeta(t *testing.T) {
+	type Value struct {
+		Id       int64 `sqlike:"id"`
+		UserName string
+		Note     sql.NullString
+		Ref      *int64
+		hidden   string
+	}
+
+	asserts := assert.New(t)
+
+	m := getStructMeta(reflect.TypeOf(Value{}))
+	asserts.Same(m, getStructMeta(reflect.TypeOf(Value{})))
+
+	asserts.Len(m.fields, 4)
+	asserts.Contains(m.byName, "id")
+	asserts.Contains(m.byName, "user_name")
+	asserts.Contains(m.byName, "note")
+	asserts.NotContains(m.byName, "hidden")
+
+	indexes, err := m.indexes([]string{"id", "unknown", "ref"})
+	asserts.Nil(err)
+	asserts.Equal([][]int{{0}, nil, {3}}, indexes)
+
+	// JOINの結果で同名のカラムが複数ある場合は、同じフィールドに後のカラムの値を読み込む
+	indexes, err = m.indexes([]string{"id", "ref", "id"})
+	asserts.Nil(err)
+	asserts.Equal([][]int{{0}, {3}, {0}}, indexes)
+}
+
+type timestamps struct {
+	CreatedAt time.Time `sqlike:"created_at"`
+	UpdatedAt time.Time `sqlike:"updated_at"`
+}
+
+func TestGetStructMeta_Nested(t *testing.T) {
+	type Author struct {
+		Id   int64  `sqlike:"id"`
+		Name string `sqlike:"name"`
+	}
+	type Book struct {
+		timestamps
+		Id    int64  `sqlike:"id"`
+		Title string `sqlike:"title"`
+	}
+
+	t.Run("Embedded", func(t *testing.T) {
+		type Value struct {
+			Book
+			Note sql.NullString `sqlike:"note"`
+		}
+
+		asserts := assert.New(t)
+		m := getStructMeta(reflect.TypeOf(Value{}))
+		names := make([]string, 0)
+		for _, f := range m.fields {
+			names = append(names, f.name)
+		}
+		asserts.Equal([]string{"created_at", "updated_at", "id", "title", "note"}, names)
+		asserts.Equal([]int{0, 0, 1}, m.byName["updated_at"].index)
+	})
+
+	t.Run("Shadowed", func(t *testing.T) {
+		type Value struct {
+			Author
+			Id string `sqlike:"id"`
+		}
+
+		asserts := assert.New(t)
+		m := getStructMeta(reflect.TypeOf(Value{}))
+		asserts.Equal([]int{1}, m.byName["id"].index)
+		indexes, err := m.indexes([]string{"id", "name"})
+		asserts.Nil(err)
+		asserts.Equal([][]int{{1}, {0, 1}}, indexes)
+	})
+
+	t.Run("Prefix", func(t *testing.T) {
+		type Value struct {
+			Book
+			Author Author `sqlike:",prefix=author_"`
+		}
+
+		asserts := assert.New(t)
+		m := getStructMeta(reflect.TypeOf(Value{}))
+		asserts.Equal([]int{1, 0}, m.byName["author_id"].index)
+		asserts.Equal([]int{1, 1}, m.byName["author_name"].index)
+
+		_, err := m.indexes([]string{"id", "author_id", "author_id"})
+		asserts.True(errors.Is(err, ErrorAmbiguousColumn))
+	})
+
+	t.Run("DefaultPrefix", func(t *testing.T) {
+		type Value struct {
+			Book
+			Writer Author
+		}
+
+		asserts := assert.New(t)
+		m := getStructMeta(reflect.TypeOf(Value{}))
+		asserts.Equal([]int{0, 1}, m.byName["id"].index)
+		asserts.Equal([]int{1, 0}, m.byName["writer_id"].index)
+		asserts.Equal([]int{1, 1}, m.byName["writer_name"].index)
+		asserts.NotContains(m.byName, "name")
+	})
+
+	t.Run("Ambiguous", func(t *testing.T) {
+		type BookWithAuthor struct {
+			Book
+			AuthorId int64 `sqlike:"author_id"`
+		}
+		type Value struct {
+			BookWithAuthor
+			Author Author
+		}
+
+		// 埋め込みのauthor_idと、入れ子のAuthor.Idのauthor_idが同じ深さにある
+		asserts := assert.New(t)
+		m := getStructMeta(reflect.TypeOf(Value{}))
+		_, err := m.indexes([]string{"title", "author_id"})
+		asserts.True(errors.Is(err, ErrorAmbiguousColumn))
+		_, err = getOrderedColumnName(&Value{})
+		asserts.True(errors.Is(err, ErrorAmbiguousColumn))
+
+		indexes, err := m.indexes([]string{"title", "author_name"})
+		asserts.Nil(err)
+		asserts.Equal([][]int{{0, 0, 2}, {1, 1}}, indexes)
+	})
+
+	t.Run("PointerEmbedded", func(t *testing.T) {
+		type Base struct {
+			Id int64 `sqlike:"id"`
+		}
+		type Value struct {
+			*Base
+			Title string `sqlike:"title"`
+		}
+
+		asserts := assert.New(t)
+
+		v := Value{}
+		rv, m, err := structValue(&v)
+		asserts.Nil(err)
+		indexes, err := m.indexes([]string{"id", "title"})
+		asserts.Nil(err)
+		ptrs := fieldPtrs(rv, indexes)
+		*(ptrs[0].(*int64)) = 1
+		*(ptrs[1].(*string)) = "Hamlet"
+		asserts.Equal(Value{Base: &Base{Id: 1}, Title: "Hamlet"}, v)
+
+		// nilの埋め込みのカラムは挿入するカラムに含めない
+		columns, err := getOrderedColumnName(&Value{Title: "Hamlet"})
+		asserts.Nil(err)
+		asserts.Equal([]string{"title"}, columns)
+		columns, err = getOrderedColumnName(&v)
+		asserts.Nil(err)
+		asserts.Equal([]string{"id", "title"}, columns)
+
+		_, err = fieldValues(&Value{Title: "Hamlet"}, []string{"id", "title"})
+		asserts.True(errors.Is(err, ErrorNilEmbeddedStruct))
+	})
+
+	t.Run("RecursivePointer", func(t *testing.T) {
+		type Node struct {
+			*Node
+			Id int64 `sqlike:"id"`
+		}
+
+		asserts := assert.New(t)
+		m := getStructMeta(reflect.TypeOf(Node{}))
+		asserts.Len(m.fields, 1)
+		asserts.Equal([]int{1}, m.byName["id"].index)
+	})
+}
+
+type benchRow struct {
+	Id    int64   `sqlike:"id"`
+	Name  string  `sqlike:"name"`
+	Score float64 `sqlike:"score"`
+	Note  sql.NullString
+}
+
+// openBenchDB 100k行のテーブルを持つin-memoryのSQLiteを開く
+func openBenchDB(b *testing.B, rows int) *RootStep {
+	db, err := sql.Open(dialect.Sqlite3, ":memory:")
+	if err != nil {
+		b.Fatal(err)
+	}
+	// in-memoryのDBはコネクション毎に作られるため、コネクションを1つに制限する
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec("CREATE TABLE bench (id INTEGER PRIMARY KEY, name TEXT, score REAL, note TEXT)"); err != nil {
+		b.Fatal(err)
+	}
+	if _, err := db.Exec(
+		"WITH RECURSIVE seq(n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM seq WHERE n < ?) "+
+			"INSERT INTO bench SELECT n, 'name' || n, n * 0.5, NULL FROM seq", rows); err != nil {
+		b.Fatal(err)
+	}
+
+	d, _ := dialect.Get(dialect.Sqlite3)
+	return NewRootStep(context.Background(), d, db.QueryContext, db.ExecContext)
+}
+
+func BenchmarkFetchInto(b *testing.B) {
+	r := openBenchDB(b, 100000)
+	bench := model.NewTable("bench")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rows := make([]benchRow, 0)
+		if err := NewSelectFromBranchStep(r, bench).Build().FetchInto(&rows); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkInsertRecords(b *testing.B) {
+	bench := model.NewTable("bench")
+	records := make([]*model.Record, 0)
+	for i := 0; i < 1000; i++ {
+		records = append(records, &model.Record{Value: &benchRow{Id: int64(i), Name: "name"}})
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := NewInsertIntoBranchStep(root(dialect.MySQL), bench).Record(records...).Build().StatementAndBindings(); err != nil {
+			b.Fatal(err)
+		}
+	}
 }
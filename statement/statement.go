@@ -4,18 +4,30 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"github.com/tmarcus87/sqlike/dialect"
 	"github.com/tmarcus87/sqlike/logger"
+	"github.com/tmarcus87/sqlike/model"
 	"reflect"
 	"strings"
 )
 
 var (
 	ErrorNoSteps          = errors.New("no steps")
+	ErrorNoDialect        = errors.New("no dialect")
 	ErrorMustBeASlice     = errors.New("must be a slice")
 	ErrorMustBeAPtr       = errors.New("must be a pointer")
 	ErrorMustBeAStructPtr = errors.New("must be a pointer to struct")
+	ErrorNativeSubQuery   = errors.New("native statement can not be used as sub query")
+	ErrorReturningExecute = errors.New("statement with returning can not be executed, fetch the returned rows by FetchInto and so on instead")
 )
 
+// ScanTargets is implemented by the row struct generated by sqlikegen.
+// FetchInto, FetchOneInto and Cursor scan the row into the targets without reflection when the struct implements it.
+type ScanTargets interface {
+	// SQLikeScanTargets returns the pointers to the fields for the columns, and nil for the unknown column
+	SQLikeScanTargets(columns []string) []interface{}
+}
+
 type DummyScanner struct {
 }
 
@@ -38,7 +50,20 @@ type Statement interface {
 	FetchMap() ([]map[string]string, error)
 	FetchInto(p interface{}) error
 	FetchOneInto(p interface{}) (bool, error)
+
+	// Cursor executes the query and returns Cursor which scans each row into p(pointer to struct).
+	// The caller must close the Cursor.
+	Cursor(p interface{}) (Cursor, error)
+
+	// Iterate scans each row into p(pointer to struct) and calls fn.
+	// The iteration terminates when fn returns an error, and ErrorStopIteration is treated as success.
+	Iterate(p interface{}, fn func() error) error
+
 	Execute() Result
+
+	// SQLikeSubQuery returns the statement to be embedded into other statement,
+	// which is built for the dialect of the outer statement.
+	model.SubQuery
 }
 
 type StatementImpl struct {
@@ -48,6 +73,7 @@ type StatementImpl struct {
 	Statement string
 	Bindings  []interface{}
 	State     map[string]interface{}
+	Dialect   dialect.Dialect
 
 	queryer Queryer
 }
@@ -96,6 +122,9 @@ func (s *StatementImpl) FetchMap() ([]map[string]string, error) {
 
 		res = append(res, vmap)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 	return res, nil
 }
 
@@ -139,11 +168,16 @@ func (s *StatementImpl) FetchInto(p interface{}) error {
 		return err
 	}
 
+	toPtrs, err := newFieldPtrs(elementType, names)
+	if err != nil {
+		return err
+	}
+
 	for rows.Next() {
 		// elementはpointer
-		element := reflect.New(elementType).Interface()
+		element := reflect.New(elementType)
 
-		vptrs, err := s.toFieldPtr(element, names)
+		vptrs, err := toPtrs(element)
 		if err != nil {
 			return err
 		}
@@ -151,18 +185,17 @@ func (s *StatementImpl) FetchInto(p interface{}) error {
 			return err
 		}
 
-		logger.Debug("Fetch record to struct : %+v", element)
+		logger.Debug("Fetch record to struct : %+v", element.Interface())
 
 		// 元のSliceの値が値の場合はpointerから値に戻す
-		elementValue := reflect.ValueOf(element)
 		if !isPtrElement {
-			elementValue = elementValue.Elem()
+			element = element.Elem()
 		}
 
-		sliceValue.Set(reflect.Append(sliceValue, elementValue))
+		sliceValue.Set(reflect.Append(sliceValue, element))
 	}
 
-	return nil
+	return rows.Err()
 }
 
 func (s *StatementImpl) FetchOneInto(p interface{}) (bool, error) {
@@ -202,7 +235,7 @@ func (s *StatementImpl) FetchOneInto(p interface{}) (bool, error) {
 	}
 
 	if !rows.Next() {
-		return false, nil
+		return false, rows.Err()
 	}
 
 	vptrs, err := s.toFieldPtr(p, names)
@@ -217,30 +250,56 @@ func (s *StatementImpl) FetchOneInto(p interface{}) (bool, error) {
 }
 
 func (s *StatementImpl) toFieldPtr(p interface{}, names []string) ([]interface{}, error) {
-	t := reflect.TypeOf(reflect.Indirect(reflect.ValueOf(p)).Interface())
-
-	name2index := make(map[string]int)
-	for i := 0; i < t.NumField(); i++ {
-		f := t.Field(i)
-		name2index[getColumnName(f)] = i
+	v := reflect.ValueOf(p)
+	toPtrs, err := newFieldPtrs(v.Type().Elem(), names)
+	if err != nil {
+		return nil, err
 	}
+	return toPtrs(v)
+}
 
-	val := reflect.ValueOf(p).Elem()
+var scanTargetsType = reflect.TypeOf((*ScanTargets)(nil)).Elem()
 
-	logger.Debug("Get FieldPtr for %T(%+v) <= %+v", val.Interface(), names, name2index)
+// newFieldPtrs 行ごとにフィールドを探さないように、カラムに対応するフィールドを一度だけ求めて、
+// 要素(構造体のポインタ)からフィールドのポインタを返す関数を返す
+func newFieldPtrs(elementType reflect.Type, names []string) (func(element reflect.Value) ([]interface{}, error), error) {
+	if reflect.PtrTo(elementType).Implements(scanTargetsType) {
+		return func(element reflect.Value) ([]interface{}, error) {
+			return scanTargets(element.Interface().(ScanTargets), names)
+		}, nil
+	}
 
-	vptrs := make([]interface{}, 0)
-	for _, name := range names {
-		fi, ok := name2index[name]
-		if !ok {
-			logger.Debug("skip '%s' field for %T", name, val.Interface())
-			vptrs = append(vptrs, DummyScanner{})
-		} else {
-			valueField := val.Field(fi)
-			vptrs = append(vptrs, valueField.Addr().Interface())
+	if elementType.Kind() != reflect.Struct {
+		return nil, ErrorMustBeAStructPtr
+	}
+
+	indexes, err := getStructMeta(elementType).indexes(names)
+	if err != nil {
+		return nil, err
+	}
+	for i, index := range indexes {
+		if index == nil {
+			logger.Debug("skip '%s' field for %s", names[i], elementType)
 		}
 	}
 
+	return func(element reflect.Value) ([]interface{}, error) {
+		return fieldPtrs(element.Elem(), indexes), nil
+	}, nil
+}
+
+// scanTargets 生成されたSQLikeScanTargetsで取得する。未知のカラムはリフレクションの場合と同様に読み飛ばす
+func scanTargets(st ScanTargets, names []string) ([]interface{}, error) {
+	vptrs := st.SQLikeScanTargets(names)
+	if len(vptrs) != len(names) {
+		return nil, fmt.Errorf("%T returns %d scan targets for %d columns", st, len(vptrs), len(names))
+	}
+	for i, vptr := range vptrs {
+		if vptr == nil {
+			logger.Debug("skip '%s' field for %T", names[i], st)
+			vptrs[i] = DummyScanner{}
+		}
+	}
 	return vptrs, nil
 }
 
@@ -249,9 +308,23 @@ func (s *StatementImpl) Execute() Result {
 		return &BasicResult{err: fmt.Errorf("failed to build sql : %w", err)}
 	}
 
+	// RETURNINGの結果を捨てて実行すると、行を取得するためにもう一度書き込むことになるため実行させない
+	if _, ok := s.State[StateWriteStmtReturning]; ok {
+		return &BasicResult{err: ErrorReturningExecute}
+	}
+
 	result, err := s.queryer.Execute(s.Statement, s.Bindings...)
 
-	return &BasicResult{native: result, err: err}
+	return &BasicResult{native: result, err: err, skippable: s.skippableRows()}
+}
+
+// skippableRows INSERT IGNOREの場合のみ挿入する行数を返す。それ以外の場合はスキップされる行はない
+func (s *StatementImpl) skippableRows() int64 {
+	if _, ok := s.State[StateInsertStmtIgnore]; !ok {
+		return 0
+	}
+	rows, _ := s.State[StateInsertStmtRows].(int64)
+	return rows
 }
 
 func (s *StatementImpl) StatementAndBindings() (string, []interface{}, error) {
@@ -262,11 +335,45 @@ func (s *StatementImpl) StatementAndBindings() (string, []interface{}, error) {
 	return s.Statement, s.Bindings, nil
 }
 
+func (s *StatementImpl) SQLikeSubQuery(d dialect.Dialect, bindings *[]interface{}) (string, error) {
+	sub, err := s.subStatement(d, *bindings)
+	if err != nil {
+		return "", err
+	}
+	*bindings = sub.Bindings
+	return sub.Statement, nil
+}
+
+// subStatement 外側のStatementのdialectで組み立てる。プレースホルダの番号を続けるため、外側のバインド変数に続けて追加する
+func (s *StatementImpl) subStatement(d dialect.Dialect, bindings []interface{}) (*StatementImpl, error) {
+	sub := &StatementImpl{sa: s.sa, Dialect: d, Bindings: bindings}
+	if err := sub.acceptSteps(); err != nil {
+		return nil, err
+	}
+	if _, ok := sub.State[StateNativeStatement]; ok {
+		return nil, ErrorNativeSubQuery
+	}
+	return sub, nil
+}
+
 func (s *StatementImpl) buildStatement() error {
 	if s.built {
 		return nil
 	}
 
+	if err := s.acceptSteps(); err != nil {
+		return err
+	}
+	s.built = true
+
+	logger.Debug("Built statement")
+	logger.Debug("Statement : %s", s.Statement)
+	logger.Debug("Bindings  : %+v", s.Bindings)
+	return nil
+}
+
+// acceptSteps 各Stepを適用してStatementを組み立てる。サブクエリはdialectが指定されている
+func (s *StatementImpl) acceptSteps() error {
 	steps := getSteps(s.sa)
 
 	// RootStepがQueryerでなければバグのためpanic
@@ -276,7 +383,14 @@ func (s *StatementImpl) buildStatement() error {
 		return fmt.Errorf("RootStep(%T) is not a Queryer", rootStep)
 	}
 
+	if q.Dialect() == nil {
+		return ErrorNoDialect
+	}
+
 	s.State = make(map[string]interface{})
+	if s.Dialect == nil {
+		s.Dialect = q.Dialect()
+	}
 	s.queryer = q
 	for _, step := range steps {
 		if err := step.Accept(s); err != nil {
@@ -284,12 +398,11 @@ func (s *StatementImpl) buildStatement() error {
 		}
 	}
 
-	s.Statement = strings.TrimSuffix(s.Statement, " ")
-	s.built = true
+	if err := checkWriteWithoutWhere(s, rootStep); err != nil {
+		return err
+	}
 
-	logger.Debug("Built statement")
-	logger.Debug("Statement : %s", s.Statement)
-	logger.Debug("Bindings  : %+v", s.Bindings)
+	s.Statement = strings.TrimSuffix(s.Statement, " ")
 	return nil
 }
 
@@ -309,30 +422,28 @@ func getSteps(lastStep StatementAcceptor) []StatementAcceptor {
 	return steps
 }
 
-func getQueryer(lastStep StatementAcceptor) (Queryer, error) {
-	steps := getSteps(lastStep)
-
-	if len(steps) == 0 {
-		return nil, ErrorNoSteps
-	}
-
-	q, ok := steps[0].(Queryer)
-	if !ok {
-		return nil, fmt.Errorf("RootStep(%T) is not a Queryer", q)
-	}
-
-	return q, nil
+// bind バインド変数を追加し、dialectのプレースホルダを返す
+func bind(stmt *StatementImpl, value interface{}) string {
+	stmt.Bindings = append(stmt.Bindings, value)
+	return stmt.Dialect.Placeholder(len(stmt.Bindings))
 }
 
 type Result interface {
 	Error() error
 	AffectedRows() (int64, error)
 	LastInsertId() (int64, error)
+
+	// SkippedRows returns the number of rows which are not inserted by the insert ignore mode(OnDuplicateKeyIgnore),
+	// which is always 0 for other statements.
+	SkippedRows() (int64, error)
 }
 
 type BasicResult struct {
 	native sql.Result
 	err    error
+
+	// skippable INSERT IGNOREで挿入する行数
+	skippable int64
 }
 
 func (b BasicResult) Error() error {
@@ -352,3 +463,14 @@ func (b BasicResult) LastInsertId() (int64, error) {
 	}
 	return b.native.LastInsertId()
 }
+
+func (b BasicResult) SkippedRows() (int64, error) {
+	if b.err != nil || b.skippable == 0 {
+		return 0, b.err
+	}
+	affected, err := b.native.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return b.skippable - affected, nil
+}
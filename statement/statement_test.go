@@ -0,0 +1,60 @@
+package statement
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/tmarcus87/sqlike/dialect"
+	"github.com/tmarcus87/sqlike/model"
+	"testing"
+)
+
+func TestInstantStep_Postgres(t *testing.T) {
+	stmt, bindings, err :=
+		NewInstantStep(root(dialect.Postgres), "SELECT * FROM t1 WHERE c1 = $1", []interface{}{1}).
+			StatementAndBindings()
+
+	asserts := assert.New(t)
+	asserts.Nil(err)
+	asserts.Equal("SELECT * FROM t1 WHERE c1 = $1", stmt)
+	asserts.Len(bindings, 1)
+}
+
+type bracketDialect struct {
+	dialect.Standard
+}
+
+func (d *bracketDialect) QuoteIdentifier(name string) string {
+	return "[" + name + "]"
+}
+
+func (d *bracketDialect) Placeholder(n int) string {
+	return fmt.Sprintf("@p%d", n)
+}
+
+func (d *bracketDialect) LimitOffset(limit int32, offset int64) string {
+	return fmt.Sprintf("OFFSET %d ROWS FETCH NEXT %d ROWS ONLY", offset, limit)
+}
+
+func TestCustomDialect(t *testing.T) {
+	t1 := model.NewTable("t1")
+	c1 := model.NewInt32Column(t1, "c1")
+
+	stmt, _, err :=
+		NewSelectColumnBranchStep(&RootStep{dialect: &bracketDialect{dialect.Standard{DialectName: "bracket"}}}, c1).
+			From(t1).
+			Where(c1.Eq(1)).
+			OrderBy(c1.Asc()).
+			LimitAndOffset(10, 20).
+			Build().
+			StatementAndBindings()
+
+	asserts := assert.New(t)
+	asserts.Nil(err)
+	asserts.Equal("SELECT [t1].[c1] FROM [t1] WHERE [t1].[c1] = @p1 ORDER BY [t1].[c1] ASC OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY", stmt)
+}
+
+func TestNoDialect(t *testing.T) {
+	_, _, err := NewSelectOneBranchStep(&RootStep{}).Build().StatementAndBindings()
+	assert.True(t, errors.Is(err, ErrorNoDialect))
+}
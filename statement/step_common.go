@@ -3,30 +3,51 @@ package statement
 import (
 	"context"
 	"database/sql"
-	"fmt"
 	"github.com/tmarcus87/sqlike/dialect"
 )
 
 type Queryer interface {
-	DialectStatement(st dialect.StatementType) (string, error)
+	Dialect() dialect.Dialect
 	Context() context.Context
 	Query(string, ...interface{}) (*sql.Rows, error)
 	Execute(string, ...interface{}) (sql.Result, error)
 }
 
 type RootStep struct {
-	ctx              context.Context
-	q                func(context.Context, string, ...interface{}) (*sql.Rows, error)
-	e                func(context.Context, string, ...interface{}) (sql.Result, error)
-	dialectStatement map[dialect.StatementType]string
+	ctx     context.Context
+	q       func(context.Context, string, ...interface{}) (*sql.Rows, error)
+	e       func(context.Context, string, ...interface{}) (sql.Result, error)
+	dialect dialect.Dialect
+
+	// b トランザクション外のセッションの場合のみ設定される
+	b func(context.Context, *sql.TxOptions) (*sql.Tx, error)
+
+	// allowWriteWithoutWhere 条件のないUPDATEとDELETEを許可する
+	allowWriteWithoutWhere bool
+}
+
+// AllowWriteWithoutWhere allows UPDATE and DELETE without WHERE even if AllRows is not called
+func (s *RootStep) AllowWriteWithoutWhere() *RootStep {
+	s.allowWriteWithoutWhere = true
+	return s
+}
+
+// WithTxBeginner sets the function to begin transaction, which is used by the batch statement executed in transaction
+func (s *RootStep) WithTxBeginner(b func(context.Context, *sql.TxOptions) (*sql.Tx, error)) *RootStep {
+	s.b = b
+	return s
 }
 
-func (s *RootStep) DialectStatement(st dialect.StatementType) (string, error) {
-	stmt, ok := s.dialectStatement[st]
-	if !ok {
-		return "", fmt.Errorf("no dialect statement for %v", st)
+// begin トランザクション中のセッションの場合はnilを返す
+func (s *RootStep) begin() (*sql.Tx, error) {
+	if s.b == nil {
+		return nil, nil
 	}
-	return stmt, nil
+	return s.b(s.ctx, nil)
+}
+
+func (s *RootStep) Dialect() dialect.Dialect {
+	return s.dialect
 }
 
 func (s *RootStep) Parent() StatementAcceptor {
@@ -49,17 +70,21 @@ func (s *RootStep) Execute(stmt string, args ...interface{}) (sql.Result, error)
 
 func NewRootStep(
 	ctx context.Context,
-	dialectStatement map[dialect.StatementType]string,
+	d dialect.Dialect,
 	q func(context.Context, string, ...interface{}) (*sql.Rows, error),
 	e func(context.Context, string, ...interface{}) (sql.Result, error)) *RootStep {
 	return &RootStep{
-		ctx:              ctx,
-		q:                q,
-		e:                e,
-		dialectStatement: dialectStatement,
+		ctx:     ctx,
+		q:       q,
+		e:       e,
+		dialect: d,
 	}
 }
 
+const (
+	StateNativeStatement = "NATIVE_STATEMENT"
+)
+
 type InstantStep struct {
 	parent    StatementAcceptor
 	statement string
@@ -73,6 +98,8 @@ func (s *InstantStep) Parent() StatementAcceptor {
 func (s *InstantStep) Accept(stmt *StatementImpl) error {
 	stmt.Statement += s.statement
 	stmt.Bindings = append(stmt.Bindings, s.bindings...)
+	// ユーザが指定したクエリはそのまま使うため、識別子とプレースホルダはdialectの形式で書く。サブクエリには使えない
+	stmt.State[StateNativeStatement] = true
 	return nil
 }
 
@@ -2,6 +2,10 @@ package statement
 
 import "github.com/tmarcus87/sqlike/model"
 
+const (
+	StateWhereStmtHasCondition = "WHERE_STMT_HAS_CONDITION"
+)
+
 type WhereStep struct {
 	parent     StatementAcceptor
 	conditions []model.Condition
@@ -17,8 +21,11 @@ func (s *WhereStep) Accept(stmt *StatementImpl) error {
 	}
 
 	stmt.Statement += "WHERE "
+	stmt.State[StateWhereStmtHasCondition] = true
 
-	joinCondition(s.conditions, &stmt.Statement, &stmt.Bindings, "AND")
+	if err := joinCondition(stmt, s.conditions, &stmt.Statement, &stmt.Bindings, "AND"); err != nil {
+		return err
+	}
 
 	stmt.Statement += " "
 
@@ -1,6 +1,7 @@
 package statement
 
 import (
+	"github.com/tmarcus87/sqlike/dialect"
 	"github.com/tmarcus87/sqlike/model"
 )
 
@@ -9,7 +10,11 @@ type AndCondition struct {
 }
 
 func (c *AndCondition) Apply(stmt *string, bindings *[]interface{}) {
-	joinCondition(c.conditions, stmt, bindings, "AND")
+	model.JoinCondition(c.conditions, stmt, bindings, "AND")
+}
+
+func (c *AndCondition) ApplyWithDialect(d dialect.Dialect, stmt *string, bindings *[]interface{}) error {
+	return model.JoinConditionWithDialect(d, c.conditions, stmt, bindings, "AND")
 }
 
 func (c *AndCondition) And(condition model.Condition) model.Condition {
@@ -31,7 +36,11 @@ type OrCondition struct {
 }
 
 func (c *OrCondition) Apply(stmt *string, bindings *[]interface{}) {
-	joinCondition(c.conditions, stmt, bindings, "OR")
+	model.JoinCondition(c.conditions, stmt, bindings, "OR")
+}
+
+func (c *OrCondition) ApplyWithDialect(d dialect.Dialect, stmt *string, bindings *[]interface{}) error {
+	return model.JoinConditionWithDialect(d, c.conditions, stmt, bindings, "OR")
 }
 
 func (c *OrCondition) And(condition model.Condition) model.Condition {
@@ -48,6 +57,6 @@ func Or(conditions ...model.Condition) model.Condition {
 	}
 }
 
-func joinCondition(conditions []model.Condition, stmt *string, bindings *[]interface{}, operator string) {
-	model.JoinCondition(conditions, stmt, bindings, operator)
+func joinCondition(stmt *StatementImpl, conditions []model.Condition, s *string, bindings *[]interface{}, operator string) error {
+	return model.JoinConditionWithDialect(stmt.Dialect, conditions, s, bindings, operator)
 }
@@ -8,6 +8,9 @@ import (
 type DeleteFromStep struct {
 	parent StatementAcceptor
 	table  model.Table
+
+	// joined 他のテーブルと結合する場合は削除対象のテーブルを指定する
+	joined bool
 }
 
 func (s *DeleteFromStep) Parent() StatementAcceptor {
@@ -15,6 +18,15 @@ func (s *DeleteFromStep) Parent() StatementAcceptor {
 }
 
 func (s *DeleteFromStep) Accept(stmt *StatementImpl) error {
-	stmt.Statement += fmt.Sprintf("DELETE FROM %s ", s.table.SQLikeTableExpr())
+	stmt.State[StateWriteStmt] = true
+	expr, err := tableExpr(stmt, s.table)
+	if err != nil {
+		return err
+	}
+	if s.joined {
+		stmt.Statement += fmt.Sprintf("DELETE %s FROM %s ", stmt.Dialect.QuoteIdentifier(s.table.SQLikeAliasOrName()), expr)
+		return nil
+	}
+	stmt.Statement += fmt.Sprintf("DELETE FROM %s ", expr)
 	return nil
 }
@@ -1,6 +1,7 @@
 package statement
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/tmarcus87/sqlike/dialect"
 	"github.com/tmarcus87/sqlike/model"
@@ -12,8 +13,37 @@ func TestDeleteFromStep_Accept(t *testing.T) {
 	c1 := model.NewBoolColumn(t1, "c1")
 
 	t.Run("WithoutWhere", func(t *testing.T) {
+		_, _, err :=
+			NewDeleteFromBranchStep(root(dialect.MySQL), t1).
+				Build().
+				StatementAndBindings()
+
+		asserts := assert.New(t)
+		asserts.True(errors.Is(err, ErrorWriteWithoutWhere))
+
+		_, _, err =
+			NewDeleteFromBranchStep(root(dialect.MySQL), t1).
+				Where().
+				Build().
+				StatementAndBindings()
+		asserts.True(errors.Is(err, ErrorWriteWithoutWhere))
+	})
+
+	t.Run("AllRows", func(t *testing.T) {
 		stmt, _, err :=
 			NewDeleteFromBranchStep(root(dialect.MySQL), t1).
+				AllRows().
+				Build().
+				StatementAndBindings()
+
+		asserts := assert.New(t)
+		asserts.Nil(err)
+		asserts.Equal("DELETE FROM `t1`", stmt)
+	})
+
+	t.Run("AllowWriteWithoutWhere", func(t *testing.T) {
+		stmt, _, err :=
+			NewDeleteFromBranchStep(root(dialect.MySQL).AllowWriteWithoutWhere(), t1).
 				Build().
 				StatementAndBindings()
 
@@ -36,3 +66,86 @@ func TestDeleteFromStep_Accept(t *testing.T) {
 		asserts.Equal(true, bindings[0])
 	})
 }
+
+func TestDeleteJoin_Accept(t *testing.T) {
+	t1 := model.NewTable("t1").As("a")
+	t1c1 := model.NewInt32Column(t1, "c1")
+
+	t2 := model.NewTable("t2")
+	t2c1 := model.NewInt32Column(t2, "c1")
+	t2c2 := model.NewTextColumn(t2, "c2")
+
+	t.Run("Join", func(t *testing.T) {
+		stmt, bindings, err :=
+			NewDeleteFromBranchStep(root(dialect.MySQL), t1).
+				InnerJoin(t2, t2c1.EqCol(t1c1)).
+				Where(t2c2.Eq("foo")).
+				Build().
+				StatementAndBindings()
+
+		asserts := assert.New(t)
+		asserts.Nil(err)
+		asserts.Equal("DELETE `a` FROM `t1` AS `a` INNER JOIN `t2` ON `t2`.`c1` = `a`.`c1` WHERE `t2`.`c2` = ?", stmt)
+		asserts.Equal([]interface{}{"foo"}, bindings)
+	})
+
+	t.Run("NotSupported", func(t *testing.T) {
+		_, _, err :=
+			NewDeleteFromBranchStep(root(dialect.Sqlite3), t1).
+				LeftOuterJoin(t2, t2c1.EqCol(t1c1)).
+				Build().
+				StatementAndBindings()
+		assert.True(t, errors.Is(err, dialect.ErrorNotSupported))
+	})
+}
+
+func TestDeleteOrderByLimit_Accept(t *testing.T) {
+	t1 := model.NewTable("t1")
+	c1 := model.NewInt32Column(t1, "c1")
+
+	stmt, bindings, err :=
+		NewDeleteFromBranchStep(root(dialect.MySQL), t1).
+			Where(c1.Lt(100)).
+			OrderBy(c1.Asc()).
+			Limit(1000).
+			Build().
+			StatementAndBindings()
+
+	asserts := assert.New(t)
+	asserts.Nil(err)
+	asserts.Equal("DELETE FROM `t1` WHERE `t1`.`c1` < ? ORDER BY `t1`.`c1` ASC LIMIT 1000", stmt)
+	asserts.Equal([]interface{}{int32(100)}, bindings)
+
+	stmt, _, err =
+		NewDeleteFromBranchStep(root(dialect.MySQL), t1).
+			AllRows().
+			Limit(1000).
+			Build().
+			StatementAndBindings()
+	asserts.Nil(err)
+	asserts.Equal("DELETE FROM `t1` LIMIT 1000", stmt)
+}
+
+func TestDeleteReturning_Accept(t *testing.T) {
+	t1 := model.NewTable("t1")
+	id := model.NewInt64Column(t1, "id")
+
+	stmt, bindings, err :=
+		NewDeleteFromBranchStep(root(dialect.Sqlite3), t1).
+			Where(id.Eq(1)).
+			Returning(id).
+			Build().
+			StatementAndBindings()
+
+	asserts := assert.New(t)
+	asserts.Nil(err)
+	asserts.Equal(`DELETE FROM "t1" WHERE "t1"."id" = ? RETURNING "id"`, stmt)
+	asserts.Equal([]interface{}{int64(1)}, bindings)
+
+	_, _, err =
+		NewDeleteFromBranchStep(root(dialect.Sqlite3), t1).
+			Returning(id).
+			Build().
+			StatementAndBindings()
+	asserts.True(errors.Is(err, ErrorWriteWithoutWhere))
+}
@@ -3,7 +3,6 @@ package statement
 import (
 	"errors"
 	"fmt"
-	"github.com/tmarcus87/sqlike/dialect"
 	"github.com/tmarcus87/sqlike/model"
 	"strings"
 )
@@ -14,8 +13,11 @@ var (
 )
 
 const (
+	StateInsertStmtPosition                = "INSERT_STMT_POSITION"
 	StateInsertStmtColumns                 = "INSERT_STMT_COLUMNS"
 	StateInsertStmtHasValue                = "INSERT_STMT_HAS_VALUE"
+	StateInsertStmtRows                    = "INSERT_STMT_ROWS"
+	StateInsertStmtIgnore                  = "INSERT_STMT_IGNORE"
 	StateInsertOnDuplicateKeyUpdateStmtSet = "INSERT_STMT_DUPLICATE_UPDATE_SET"
 )
 
@@ -29,7 +31,13 @@ func (s *InsertIntoStep) Parent() StatementAcceptor {
 }
 
 func (s *InsertIntoStep) Accept(stmt *StatementImpl) error {
-	stmt.Statement += fmt.Sprintf("INSERT INTO %s ", s.table.SQLikeTableExpr())
+	// INSERT IGNOREなどでキーワードを置き換えるため、位置を保持する
+	expr, err := tableExpr(stmt, s.table)
+	if err != nil {
+		return err
+	}
+	stmt.State[StateInsertStmtPosition] = len(stmt.Statement)
+	stmt.Statement += fmt.Sprintf("INSERT INTO %s ", expr)
 	return nil
 }
 
@@ -45,7 +53,7 @@ func (s *InsertIntoColumnStep) Parent() StatementAcceptor {
 func (s *InsertIntoColumnStep) Accept(stmt *StatementImpl) error {
 	cols := make([]string, 0)
 	for _, column := range s.columns {
-		cols = append(cols, fmt.Sprintf("`%s`", column.ColumnName()))
+		cols = append(cols, stmt.Dialect.QuoteIdentifier(column.ColumnName()))
 	}
 	stmt.Statement += fmt.Sprintf("(%s) ", strings.Join(cols, ", "))
 	stmt.State[StateInsertStmtColumns] = s.columns
@@ -69,9 +77,9 @@ func (s *InsertIntoValuesStep) Accept(stmt *StatementImpl) error {
 		stmt.Statement += "VALUES "
 	}
 
-	stmt.Statement += insertValueStatement(len(s.values)) + " "
-	stmt.Bindings = append(stmt.Bindings, s.values...)
+	stmt.Statement += insertValueStatement(stmt, s.values) + " "
 	stmt.State[StateInsertStmtHasValue] = true
+	addInsertRows(stmt, 1)
 	return nil
 }
 
@@ -97,11 +105,6 @@ func (s *InsertIntoValueStructStep) Accept(stmt *StatementImpl) error {
 	}
 
 	for _, value := range s.values {
-		fvm, err := getColumnName2FieldValueMap(value)
-		if err != nil {
-			return err
-		}
-
 		columnsV, ok := stmt.State[StateInsertStmtColumns]
 		if !ok {
 			return ErrorNoColumnInfo
@@ -115,21 +118,41 @@ func (s *InsertIntoValueStructStep) Accept(stmt *StatementImpl) error {
 			stmt.Statement += ", "
 		}
 
-		stmt.Statement += insertValueStatement(len(columns))
-		stmt.State[StateInsertStmtHasValue] = true
-
+		names := make([]string, 0, len(columns))
 		for _, column := range columns {
-			fv, ok := fvm[column.ColumnName()]
-			if !ok {
-				return fmt.Errorf("struct field for '%s' is not found", column)
-			}
-			stmt.Bindings = append(stmt.Bindings, fv.Interface())
+			names = append(names, column.ColumnName())
+		}
+		values, err := fieldValues(value, names)
+		if err != nil {
+			return err
 		}
+
+		stmt.Statement += insertValueStatement(stmt, values)
+		stmt.State[StateInsertStmtHasValue] = true
 	}
+	stmt.Statement += " "
+	addInsertRows(stmt, len(s.values))
 
 	return nil
 }
 
+// addInsertRows 挿入する行数を数える。INSERT IGNOREでスキップされた行数を求めるために使う
+func addInsertRows(stmt *StatementImpl, n int) {
+	rows, _ := stmt.State[StateInsertStmtRows].(int64)
+	stmt.State[StateInsertStmtRows] = rows + int64(n)
+}
+
+func (s *InsertIntoValueStructStep) numOfRows() int {
+	return len(s.values)
+}
+
+func (s *InsertIntoValueStructStep) chunk(from, to int) StatementAcceptor {
+	return &InsertIntoValueStructStep{
+		parent: s.parent,
+		values: s.values[from:to],
+	}
+}
+
 type InsertIntoValueRecordStep struct {
 	parent  StatementAcceptor
 	records []*model.Record
@@ -140,30 +163,36 @@ func (s *InsertIntoValueRecordStep) Parent() StatementAcceptor {
 }
 
 func (s *InsertIntoValueRecordStep) Accept(stmt *StatementImpl) error {
-	if len(s.records) == 0 {
-		return ErrorNoRecors
+	_, err := applyInsertRecords(stmt, s.records)
+	return err
+}
+
+// applyInsertRecords 先頭のRecordのOnlyとSkipで挿入するカラムを決めて `(columns) VALUES (...), ...` を組み立てる
+func applyInsertRecords(stmt *StatementImpl, records []*model.Record) ([]string, error) {
+	if len(records) == 0 {
+		return nil, ErrorNoRecors
 	}
 
 	columns := make([]string, 0)
-	allColumnNames, err := getOrderedColumnName(s.records[0].Value)
+	allColumnNames, err := getOrderedColumnName(records[0].Value)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	columnNameMap := make(map[string]struct{})
 	for _, columnName := range allColumnNames {
 		columnNameMap[columnName] = struct{}{}
 	}
 
-	if len(s.records[0].Only) > 0 {
-		for _, onlyColumn := range s.records[0].Only {
+	if len(records[0].Only) > 0 {
+		for _, onlyColumn := range records[0].Only {
 			if _, ok := columnNameMap[onlyColumn.ColumnName()]; !ok {
-				return ErrorNoColumnInfo
+				return nil, ErrorNoColumnInfo
 			}
 			columns = append(columns, onlyColumn.ColumnName())
 		}
-	} else if len(s.records[0].Skip) > 0 {
+	} else if len(records[0].Skip) > 0 {
 		skipColumnNameMap := make(map[string]struct{})
-		for _, skipColumn := range s.records[0].Skip {
+		for _, skipColumn := range records[0].Skip {
 			skipColumnNameMap[skipColumn.ColumnName()] = struct{}{}
 		}
 
@@ -178,49 +207,43 @@ func (s *InsertIntoValueRecordStep) Accept(stmt *StatementImpl) error {
 
 	// todo recordsの型を確認する
 
-	bindings := make([]interface{}, 0)
-	for _, record := range s.records {
-		fvm, err := getColumnName2FieldValueMap(record.Value)
-		if err != nil {
-			return err
-		}
+	cols := make([]string, 0)
+	for _, column := range columns {
+		cols = append(cols, stmt.Dialect.QuoteIdentifier(column))
+	}
+	stmt.Statement += fmt.Sprintf("(%s) VALUES ", strings.Join(cols, ", "))
 
-		for _, column := range columns {
-			fv, ok := fvm[column]
-			if !ok {
-				return fmt.Errorf("struct field value for '%s' is not found", column)
-			}
-			bindings = append(bindings, fv.Interface())
+	rows := make([]string, 0)
+	for _, record := range records {
+		values, err := fieldValues(record.Value, columns)
+		if err != nil {
+			return nil, err
 		}
+		rows = append(rows, insertValueStatement(stmt, values))
 	}
 
-	cols := make([]string, 0)
-	for _, column := range columns {
-		cols = append(cols, "`"+column+"`")
-	}
+	stmt.Statement += strings.Join(rows, ", ") + " "
+	addInsertRows(stmt, len(records))
 
-	stmt.Statement +=
-		fmt.Sprintf(
-			"(%s) VALUES %s",
-			strings.Join(cols, ", "),
-			strings.Join(repeat(insertValueStatement(len(cols)), len(s.records)), ", "))
-	stmt.Bindings = append(stmt.Bindings, bindings...)
+	return columns, nil
+}
 
-	return nil
+func (s *InsertIntoValueRecordStep) numOfRows() int {
+	return len(s.records)
 }
 
-func repeat(s string, n int) []string {
-	res := make([]string, 0)
-	for i := 0; i < n; i++ {
-		res = append(res, s)
+func (s *InsertIntoValueRecordStep) chunk(from, to int) StatementAcceptor {
+	return &InsertIntoValueRecordStep{
+		parent:  s.parent,
+		records: s.records[from:to],
 	}
-	return res
 }
 
-func insertValueStatement(numOfValue int) string {
+// insertValueStatement binds the values and returns the row like "(?, ?)"
+func insertValueStatement(stmt *StatementImpl, values []interface{}) string {
 	vs := make([]string, 0)
-	for i := 0; i < numOfValue; i++ {
-		vs = append(vs, "?")
+	for _, value := range values {
+		vs = append(vs, bind(stmt, value))
 	}
 	return fmt.Sprintf("(%s)", strings.Join(vs, ", "))
 }
@@ -234,16 +257,20 @@ func (s *InsertOnDuplicateKeyIgnoreStep) Parent() StatementAcceptor {
 }
 
 func (s *InsertOnDuplicateKeyIgnoreStep) Accept(stmt *StatementImpl) error {
-	q, err := getQueryer(s)
+	insert, suffix, err := stmt.Dialect.InsertIgnore()
 	if err != nil {
 		return err
 	}
 
-	st, err := q.DialectStatement(dialect.StatementTypeOnDuplicateKeyIgnore)
-	if err != nil {
-		return err
+	pos, ok := stmt.State[StateInsertStmtPosition].(int)
+	if !ok {
+		return fmt.Errorf("insert ignore must follow insert into")
 	}
-	stmt.Statement += st + " "
+	stmt.Statement = stmt.Statement[:pos] + insert + strings.TrimPrefix(stmt.Statement[pos:], "INSERT INTO")
+	if suffix != "" {
+		stmt.Statement += suffix + " "
+	}
+	stmt.State[StateInsertStmtIgnore] = true
 	return nil
 }
 
@@ -256,12 +283,7 @@ func (s *InsertOnDuplicateKeyUpdateStep) Parent() StatementAcceptor {
 }
 
 func (s *InsertOnDuplicateKeyUpdateStep) Accept(stmt *StatementImpl) error {
-	q, err := getQueryer(s)
-	if err != nil {
-		return err
-	}
-
-	st, err := q.DialectStatement(dialect.StatementTypeOnDuplicateKeyUpdate)
+	st, err := stmt.Dialect.OnDuplicateKeyUpdate()
 	if err != nil {
 		return err
 	}
@@ -282,12 +304,11 @@ func (s *InsertOnDuplicateKeyUpdateSetStep) Accept(stmt *StatementImpl) error {
 	if _, ok := stmt.State[StateInsertOnDuplicateKeyUpdateStmtSet]; ok {
 		stmt.Statement = strings.TrimSuffix(stmt.Statement, " ")
 		stmt.Statement += ", "
-	} else {
-		stmt.Statement += "SET "
 	}
-	stmt.Statement += fmt.Sprintf("`%s` = ?", s.columnValue.ColumnName())
-	stmt.Bindings = append(stmt.Bindings, s.columnValue.ColumnValue())
-	stmt.State[StateUpdateStmtSet] = true
+	if err := applyAssignment(stmt, s.columnValue); err != nil {
+		return err
+	}
+	stmt.State[StateInsertOnDuplicateKeyUpdateStmtSet] = true
 	return nil
 }
 
@@ -301,5 +322,5 @@ func (s *InsertOnDuplicateKeyUpdateSetRecordStep) Parent() StatementAcceptor {
 }
 
 func (s *InsertOnDuplicateKeyUpdateSetRecordStep) Accept(stmt *StatementImpl) error {
-	return ApplySetStatement(stmt, s.record)
+	return ApplyAssignments(stmt, s.record)
 }
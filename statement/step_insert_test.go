@@ -1,6 +1,10 @@
 package statement
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
 	"github.com/tmarcus87/sqlike/dialect"
 	"github.com/tmarcus87/sqlike/model"
@@ -426,3 +430,192 @@ func TestInsertIntoSelect_Accept(t *testing.T) {
 	})
 
 }
+
+func TestInsertReturning_Accept(t *testing.T) {
+	t1 := model.NewTable("t1")
+	id := model.NewInt64Column(t1, "id")
+	c1 := model.NewInt32Column(t1, "c1")
+
+	t.Run("Postgres", func(t *testing.T) {
+		stmt, bindings, err :=
+			NewInsertIntoBranchStep(root(dialect.Postgres), t1).
+				Columns(c1).
+				Values(1).
+				OnDuplicateKeyIgnore().
+				Returning(id, c1).
+				Build().
+				StatementAndBindings()
+
+		asserts := assert.New(t)
+		asserts.Nil(err)
+		asserts.Equal(`INSERT INTO "t1" ("c1") VALUES ($1) ON CONFLICT DO NOTHING RETURNING "id", "c1"`, stmt)
+		asserts.Equal([]interface{}{1}, bindings)
+	})
+
+	t.Run("AllColumns", func(t *testing.T) {
+		stmt, _, err :=
+			NewInsertIntoBranchStep(root(dialect.Sqlite3), t1).
+				Columns(c1).
+				Values(1).
+				Returning(model.NewAllColumnField()).
+				Build().
+				StatementAndBindings()
+
+		asserts := assert.New(t)
+		asserts.Nil(err)
+		asserts.Equal(`INSERT INTO "t1" ("c1") VALUES (?) RETURNING *`, stmt)
+	})
+
+	t.Run("NotSupported", func(t *testing.T) {
+		_, _, err :=
+			NewInsertIntoBranchStep(root(dialect.MySQL), t1).
+				Columns(c1).
+				Values(1).
+				Returning(id).
+				Build().
+				StatementAndBindings()
+
+		asserts := assert.New(t)
+		asserts.True(errors.Is(err, dialect.ErrorNotSupported))
+	})
+}
+
+func TestInsertOnDuplicateKeyIgnoreStep_Accept(t *testing.T) {
+	t1 := model.NewTable("t1")
+	c1 := model.NewInt32Column(t1, "c1")
+	c2 := model.NewInt32Column(t1, "c2")
+
+	tests := []struct {
+		name     string
+		dialect  string
+		expected string
+	}{
+		{
+			name:     "MySQL",
+			dialect:  dialect.MySQL,
+			expected: "INSERT IGNORE INTO `t1` (`c1`, `c2`) VALUES (?, ?), (?, ?)",
+		},
+		{
+			name:     "Postgres",
+			dialect:  dialect.Postgres,
+			expected: `INSERT INTO "t1" ("c1", "c2") VALUES ($1, $2), ($3, $4) ON CONFLICT DO NOTHING`,
+		},
+		{
+			name:     "Sqlite3",
+			dialect:  dialect.Sqlite3,
+			expected: `INSERT OR IGNORE INTO "t1" ("c1", "c2") VALUES (?, ?), (?, ?)`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stmt, bindings, err :=
+				NewInsertIntoBranchStep(root(test.dialect), t1).
+					Columns(c1, c2).
+					Values(1, 2).
+					Values(3, 4).
+					OnDuplicateKeyIgnore().
+					Build().
+					StatementAndBindings()
+
+			asserts := assert.New(t)
+			asserts.Nil(err)
+			asserts.Equal(test.expected, stmt)
+			asserts.Equal([]interface{}{1, 2, 3, 4}, bindings)
+		})
+	}
+}
+
+func TestInsertOnDuplicateKeyIgnoreStep_Execute(t *testing.T) {
+	type T1 struct {
+		C1 int32 `sqlike:"c1"`
+		C2 int32 `sqlike:"c2"`
+	}
+
+	db, err := sql.Open(dialect.Sqlite3, ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	// in-memoryのDBはコネクション毎に作られるため、コネクションを1つに制限する
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec("CREATE TABLE t1 (c1 INTEGER PRIMARY KEY, c2 INTEGER)"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("INSERT INTO t1 (c1, c2) VALUES (1, 1)"); err != nil {
+		t.Fatal(err)
+	}
+
+	d, _ := dialect.Get(dialect.Sqlite3)
+	r := NewRootStep(context.Background(), d, db.QueryContext, db.ExecContext)
+
+	t1 := model.NewTable("t1")
+	c1 := model.NewInt32Column(t1, "c1")
+	c2 := model.NewInt32Column(t1, "c2")
+
+	t.Run("Values", func(t *testing.T) {
+		result :=
+			NewInsertIntoBranchStep(r, t1).
+				Columns(c1, c2).
+				Values(1, 10).
+				Values(2, 20).
+				OnDuplicateKeyIgnore().
+				Build().
+				Execute()
+
+		asserts := assert.New(t)
+		asserts.Nil(result.Error())
+		affected, err := result.AffectedRows()
+		asserts.Nil(err)
+		asserts.Equal(int64(1), affected)
+		skipped, err := result.SkippedRows()
+		asserts.Nil(err)
+		asserts.Equal(int64(1), skipped)
+	})
+
+	t.Run("ValueStructs", func(t *testing.T) {
+		result :=
+			NewInsertIntoBranchStep(r, t1).
+				Columns(c1, c2).
+				ValueStructs(&T1{C1: 1, C2: 10}, &T1{C1: 2, C2: 20}, &T1{C1: 3, C2: 30}).
+				OnDuplicateKeyIgnore().
+				Build().
+				Execute()
+
+		asserts := assert.New(t)
+		asserts.Nil(result.Error())
+		skipped, err := result.SkippedRows()
+		asserts.Nil(err)
+		asserts.Equal(int64(2), skipped)
+	})
+
+	t.Run("RecordReturning", func(t *testing.T) {
+		rows := make([]T1, 0)
+		err :=
+			NewInsertIntoBranchStep(r, t1).
+				Record(&model.Record{Value: &T1{C1: 3, C2: 30}}, &model.Record{Value: &T1{C1: 4, C2: 40}}).
+				OnDuplicateKeyIgnore().
+				Returning(c1, c2).
+				Build().
+				FetchInto(&rows)
+
+		asserts := assert.New(t)
+		asserts.Nil(err)
+		asserts.Equal([]T1{{C1: 4, C2: 40}}, rows)
+	})
+
+	t.Run("WithoutIgnore", func(t *testing.T) {
+		result :=
+			NewInsertIntoBranchStep(r, t1).
+				Columns(c1, c2).
+				Values(5, 50).
+				Build().
+				Execute()
+
+		asserts := assert.New(t)
+		asserts.Nil(result.Error())
+		skipped, err := result.SkippedRows()
+		asserts.Nil(err)
+		asserts.Equal(int64(0), skipped)
+	})
+}
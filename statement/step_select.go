@@ -1,12 +1,31 @@
 package statement
 
 import (
+	"errors"
 	"fmt"
-	"github.com/tmarcus87/sqlike/dialect"
 	"github.com/tmarcus87/sqlike/model"
 	"strings"
 )
 
+const (
+	StateSelectOrderByStmtPosition = "SELECT_ORDER_BY_STMT_POSITION"
+	StateSelectLimitOffsetStmt     = "SELECT_LIMIT_OFFSET_STMT"
+	StateSelectGroupByStmt         = "SELECT_GROUP_BY_STMT"
+)
+
+var (
+	ErrorSeekNoOrders           = errors.New("seek requires at least one order")
+	ErrorSeekValuesMismatch     = errors.New("number of seek values must be same as orders")
+	ErrorCompoundWithOrderLimit = errors.New("select with ORDER BY or LIMIT can not be combined")
+	ErrorSeekWithGroupBy        = errors.New("seek can not be used with GROUP BY")
+)
+
+// stmtPosition ステートメントとバインド変数の位置
+type stmtPosition struct {
+	statement int
+	bindings  int
+}
+
 type SelectExplainStep struct {
 	parent StatementAcceptor
 }
@@ -16,7 +35,7 @@ func (s *SelectExplainStep) Parent() StatementAcceptor {
 }
 
 func (s *SelectExplainStep) Accept(stmt *StatementImpl) error {
-	stmt.Statement += "EXPLAIN "
+	stmt.Statement += stmt.Dialect.ExplainPrefix() + " "
 	return nil
 }
 
@@ -29,16 +48,7 @@ func (s *SelectOneStep) Parent() StatementAcceptor {
 }
 
 func (s *SelectOneStep) Accept(stmt *StatementImpl) error {
-	q, err := getQueryer(s.parent)
-	if err != nil {
-		return err
-	}
-
-	st, err := q.DialectStatement(dialect.StatementTypeSelectOne)
-	if err != nil {
-		return err
-	}
-	stmt.Statement += st
+	stmt.Statement += stmt.Dialect.SelectOne()
 	return nil
 }
 
@@ -54,8 +64,11 @@ func (s *SelectColumnStep) Parent() StatementAcceptor {
 func (s *SelectColumnStep) Accept(stmt *StatementImpl) error {
 	cols := make([]string, 0)
 	for _, column := range s.columns {
-		s := column
-		cols = append(cols, fmt.Sprintf("%s", s.FieldExpr()))
+		expr, err := model.FieldExpr(stmt.Dialect, column, &stmt.Bindings)
+		if err != nil {
+			return err
+		}
+		cols = append(cols, expr)
 	}
 	stmt.Statement += fmt.Sprintf("SELECT %s ", strings.Join(cols, ", "))
 	return nil
@@ -71,10 +84,19 @@ func (s *SelectFromStep) Parent() StatementAcceptor {
 }
 
 func (s *SelectFromStep) Accept(stmt *StatementImpl) error {
-	stmt.Statement += fmt.Sprintf("FROM %s ", s.table.SQLikeTableExpr())
+	expr, err := tableExpr(stmt, s.table)
+	if err != nil {
+		return err
+	}
+	stmt.Statement += fmt.Sprintf("FROM %s ", expr)
 	return nil
 }
 
+// tableExpr テーブル句を返す。導出テーブルの場合はバインド変数を追加する
+func tableExpr(stmt *StatementImpl, table model.Table) (string, error) {
+	return model.TableExpr(stmt.Dialect, table, &stmt.Bindings)
+}
+
 type SelectFromJoinStep struct {
 	parent     StatementAcceptor
 	table      model.Table
@@ -87,10 +109,17 @@ func (s *SelectFromJoinStep) Parent() StatementAcceptor {
 }
 
 func (s *SelectFromJoinStep) Accept(stmt *StatementImpl) error {
+	expr, err := tableExpr(stmt, s.table)
+	if err != nil {
+		return err
+	}
+
 	var onStmt string
-	joinCondition(s.conditions, &onStmt, &stmt.Bindings, "AND")
+	if err := joinCondition(stmt, s.conditions, &onStmt, &stmt.Bindings, "AND"); err != nil {
+		return err
+	}
 
-	stmt.Statement += fmt.Sprintf("%s %s ON %s ", s.joinType, s.table.SQLikeTableExpr(), onStmt)
+	stmt.Statement += fmt.Sprintf("%s %s ON %s ", s.joinType, expr, onStmt)
 	return nil
 }
 
@@ -110,20 +139,49 @@ func (s *SelectGroupByStep) Accept(stmt *StatementImpl) error {
 
 	cols := make([]string, 0)
 	for _, column := range s.columns {
-		cols =
-			append(cols,
-				fmt.Sprintf("`%s`.`%s`",
-					column.Table().SQLikeAliasOrName(),
-					column.ColumnName()))
+		expr, err := model.ColumnExpr(stmt.Dialect, column, &stmt.Bindings)
+		if err != nil {
+			return err
+		}
+		cols = append(cols, expr)
 	}
 
 	stmt.Statement += fmt.Sprintf("GROUP BY %s ", strings.Join(cols, ", "))
+	stmt.State[StateSelectGroupByStmt] = true
+	return nil
+}
+
+type SelectHavingStep struct {
+	parent     StatementAcceptor
+	conditions []model.Condition
+}
+
+func (s *SelectHavingStep) Parent() StatementAcceptor {
+	return s.parent
+}
+
+func (s *SelectHavingStep) Accept(stmt *StatementImpl) error {
+	if len(s.conditions) == 0 {
+		return nil
+	}
+
+	stmt.Statement += "HAVING "
+
+	if err := joinCondition(stmt, s.conditions, &stmt.Statement, &stmt.Bindings, "AND"); err != nil {
+		return err
+	}
+
+	stmt.Statement += " "
+
 	return nil
 }
 
 type SelectOrderByStep struct {
 	parent StatementAcceptor
 	orders []*model.SortOrder
+
+	// compound UNIONなどで結合した結果を並べ替える場合はテーブル名で修飾しない
+	compound bool
 }
 
 func (s *SelectOrderByStep) Parent() StatementAcceptor {
@@ -135,14 +193,21 @@ func (s *SelectOrderByStep) Accept(stmt *StatementImpl) error {
 		return nil
 	}
 
+	// SeekAfterでORDER BYの前に条件を挿入して組み立て直すため位置を保持する
+	stmt.State[StateSelectOrderByStmtPosition] =
+		stmtPosition{statement: len(stmt.Statement), bindings: len(stmt.Bindings)}
+
 	orders := make([]string, 0)
 	for _, order := range s.orders {
-		orders =
-			append(orders,
-				fmt.Sprintf("`%s`.`%s` %s",
-					order.Column.Table().SQLikeAliasOrName(),
-					order.Column.ColumnName(),
-					order.Order))
+		if s.compound {
+			orders = append(orders, fmt.Sprintf("%s %s", stmt.Dialect.QuoteIdentifier(order.Column.AliasOrName()), order.Order))
+			continue
+		}
+		expr, err := model.OrderExpr(stmt.Dialect, order, &stmt.Bindings)
+		if err != nil {
+			return err
+		}
+		orders = append(orders, expr)
 	}
 
 	stmt.Statement += fmt.Sprintf("ORDER BY %s ", strings.Join(orders, ", "))
@@ -160,9 +225,139 @@ func (s *SelectLimitOffsetStep) Parent() StatementAcceptor {
 }
 
 func (s *SelectLimitOffsetStep) Accept(stmt *StatementImpl) error {
-	stmt.Statement += fmt.Sprintf("LIMIT %d ", s.limit)
-	if s.offset > 0 {
-		stmt.Statement += fmt.Sprintf("OFFSET %d ", s.offset)
+	stmt.Statement += stmt.Dialect.LimitOffset(s.limit, s.offset) + " "
+	stmt.State[StateSelectLimitOffsetStmt] = true
+	return nil
+}
+
+type SelectCompoundStep struct {
+	parent   StatementAcceptor
+	operator string
+	query    model.SubQuery
+}
+
+func (s *SelectCompoundStep) Parent() StatementAcceptor {
+	return s.parent
+}
+
+func (s *SelectCompoundStep) Accept(stmt *StatementImpl) error {
+	operator, err := stmt.Dialect.SetOperator(s.operator)
+	if err != nil {
+		return err
+	}
+
+	query, err := compoundQuery(stmt, s.query)
+	if err != nil {
+		return err
 	}
+
+	stmt.Statement += fmt.Sprintf("%s %s ", operator, query)
 	return nil
 }
+
+// compoundQuery 結合するSELECTを返す
+//
+// SQLiteでは括弧で囲んだSELECTを結合できないため、ORDER BYやLIMITを持つSELECTはエラーとする
+func compoundQuery(stmt *StatementImpl, q model.SubQuery) (string, error) {
+	si, ok := q.(*StatementImpl)
+	if !ok {
+		return q.SQLikeSubQuery(stmt.Dialect, &stmt.Bindings)
+	}
+
+	sub, err := si.subStatement(stmt.Dialect, stmt.Bindings)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := sub.State[StateSelectOrderByStmtPosition]; ok {
+		return "", ErrorCompoundWithOrderLimit
+	}
+	if _, ok := sub.State[StateSelectLimitOffsetStmt]; ok {
+		return "", ErrorCompoundWithOrderLimit
+	}
+	stmt.Bindings = sub.Bindings
+	return sub.Statement, nil
+}
+
+type SelectSeekStep struct {
+	parent StatementAcceptor
+	orders []*model.SortOrder
+	values []interface{}
+}
+
+func (s *SelectSeekStep) Parent() StatementAcceptor {
+	return s.parent
+}
+
+func (s *SelectSeekStep) Accept(stmt *StatementImpl) error {
+	if len(s.orders) == 0 {
+		return ErrorSeekNoOrders
+	}
+	if len(s.orders) != len(s.values) {
+		return ErrorSeekValuesMismatch
+	}
+	if _, ok := stmt.State[StateSelectGroupByStmt]; ok {
+		return ErrorSeekWithGroupBy
+	}
+
+	// ORDER BYが出力済みの場合は取り除き、条件の後ろに組み立て直す。プレースホルダの番号を出力順に保つため
+	// OrderByに続くSeekAfterのordersはOrderByのordersと同じ
+	if pos, ok := stmt.State[StateSelectOrderByStmtPosition].(stmtPosition); ok {
+		stmt.Statement = stmt.Statement[:pos.statement]
+		stmt.Bindings = stmt.Bindings[:pos.bindings]
+	}
+
+	if _, ok := stmt.State[StateWhereStmtHasCondition]; ok {
+		stmt.Statement += "AND "
+	} else {
+		stmt.Statement += "WHERE "
+	}
+	if err := model.ApplyCondition(stmt.Dialect, seekCondition(stmt, s.orders, s.values), &stmt.Statement, &stmt.Bindings); err != nil {
+		return err
+	}
+	stmt.Statement += " "
+
+	return (&SelectOrderByStep{orders: s.orders}).Accept(stmt)
+}
+
+// seekCondition ソート順で指定した値より後ろのレコードを取得する条件を返す
+//
+// 全てのソート順が同じ方向で、dialectが対応していれば行値式で比較し、
+// そうでなければ (a > ? OR (a = ? AND b > ?)) の形式に展開する。
+func seekCondition(stmt *StatementImpl, orders []*model.SortOrder, values []interface{}) model.Condition {
+	sameDirection := true
+	for _, order := range orders {
+		if order.Order != orders[0].Order {
+			sameDirection = false
+		}
+	}
+
+	if len(orders) > 1 && sameDirection && stmt.Dialect.SupportsRowValueComparison() {
+		columns := make([]model.ColumnField, 0)
+		for _, order := range orders {
+			columns = append(columns, order.Column)
+		}
+		return &model.RowValueCondition{
+			Columns:  columns,
+			Operator: seekOperator(orders[0]),
+			Values:   values,
+		}
+	}
+
+	conditions := make([]model.Condition, 0)
+	for i, order := range orders {
+		conds := make([]model.Condition, 0)
+		for j := 0; j < i; j++ {
+			conds = append(conds, &model.SingleValueCondition{Column: orders[j].Column, Operator: "=", Value: values[j]})
+		}
+		conds = append(conds, &model.SingleValueCondition{Column: order.Column, Operator: seekOperator(order), Value: values[i]})
+		conditions = append(conditions, And(conds...))
+	}
+	return Or(conditions...)
+}
+
+func seekOperator(order *model.SortOrder) string {
+	if order.Order == model.OrderDesc {
+		return "<"
+	}
+	return ">"
+}
@@ -1,15 +1,18 @@
 package statement
 
 import (
+	"errors"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/tmarcus87/sqlike/dialect"
 	"github.com/tmarcus87/sqlike/model"
 	"testing"
 )
 
-func root(d string) *RootStep {
+func root(name string) *RootStep {
+	d, _ := dialect.Get(name)
 	return &RootStep{
-		dialectStatement: dialect.GetDialectStatements(d),
+		dialect: d,
 	}
 }
 
@@ -48,6 +51,10 @@ func TestSelectOne_Accept(t *testing.T) {
 			dialect: dialect.Sqlite3,
 			expect:  "SELECT 1",
 		},
+		{
+			dialect: dialect.Postgres,
+			expect:  "SELECT 1",
+		},
 	}
 
 	for _, test := range tests {
@@ -117,6 +124,28 @@ func TestSelectFromWithOneWhere_Accept(t *testing.T) {
 	asserts.Equal(true, bindings[0])
 }
 
+func TestSelectFromWithWhere_Postgres(t *testing.T) {
+	asserts := assert.New(t)
+
+	t1 := model.NewTable("t1")
+
+	c1 := model.NewBoolColumn(t1, "c1")
+	c2 := model.NewInt32Column(t1, "c2")
+
+	stmt, bindings, err :=
+		NewSelectColumnBranchStep(root(dialect.Postgres), c1, c2.As("c2alt")).
+			From(t1.As("t1alt")).
+			Where(And(c1.Eq(true), c2.In(1, 2))).
+			Build().
+			StatementAndBindings()
+	asserts.Nil(err)
+	asserts.Equal(`SELECT "t1alt"."c1", "t1alt"."c2" AS "c2alt" FROM "t1" AS "t1alt" WHERE ("t1alt"."c1" = $1 AND "t1alt"."c2" IN ($2, $3))`, stmt)
+	asserts.Len(bindings, 3)
+	asserts.Equal(true, bindings[0])
+	asserts.Equal(int32(1), bindings[1])
+	asserts.Equal(int32(2), bindings[2])
+}
+
 func TestSelectFromWithTwoWhere_Accept(t *testing.T) {
 	asserts := assert.New(t)
 
@@ -144,6 +173,44 @@ func TestSelectFromWithTwoWhere_Accept(t *testing.T) {
 	})
 }
 
+// legacyCondition Applyのみを実装した独自の条件
+type legacyCondition struct {
+	column model.ColumnField
+	value  interface{}
+}
+
+func (c *legacyCondition) Apply(stmt *string, bindings *[]interface{}) {
+	*stmt += fmt.Sprintf("%s >= ?", c.column.FieldExpr())
+	*bindings = append(*bindings, c.value)
+}
+
+func (c *legacyCondition) And(condition model.Condition) model.Condition {
+	return model.And(c, condition)
+}
+
+func (c *legacyCondition) Or(condition model.Condition) model.Condition {
+	return model.Or(c, condition)
+}
+
+func TestSelectFromWithUserDefinedCondition_Accept(t *testing.T) {
+	asserts := assert.New(t)
+
+	t1 := model.NewTable("t1")
+
+	c1 := model.NewBoolColumn(t1, "c1")
+	c2 := model.NewInt32Column(t1, "c2")
+
+	stmt, bindings, err :=
+		NewSelectColumnBranchStep(root(dialect.MySQL), c1).
+			From(t1).
+			Where(And(c1.Eq(true), &legacyCondition{column: c2, value: 10})).
+			Build().
+			StatementAndBindings()
+	asserts.Nil(err)
+	asserts.Equal("SELECT `t1`.`c1` FROM `t1` WHERE (`t1`.`c1` = ? AND `t1`.`c2` >= ?)", stmt)
+	asserts.Equal([]interface{}{true, 10}, bindings)
+}
+
 func TestSelectFromJoin_Accept(t *testing.T) {
 	t.Run("All", func(t *testing.T) {
 		asserts := assert.New(t)
@@ -243,6 +310,38 @@ func TestSelectFromGroupBy_Accept(t *testing.T) {
 		asserts.Nil(err)
 		asserts.Equal("SELECT `t1alt`.`c1` AS `c1alt`, COUNT(`t1alt`.`c2`) AS `cnt` FROM `t1` AS `t1alt` GROUP BY `t1alt`.`c1`", stmt)
 	})
+
+	t.Run("Expression", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		t1 := model.NewTable("t1")
+
+		c1 := model.NewTextColumn(t1, "c1")
+		c2 := model.NewTimeColumn(t1, "c2")
+
+		stmt, bindings, err :=
+			NewSelectColumnBranchStep(root(dialect.Postgres), model.Concat(c1, "-").As("c1"), model.CountAll()).
+				From(t1).
+				Where(c2.IsNotNull()).
+				GroupBy(model.Concat(c1, "-"), model.DateFormat(c2, "YYYY")).
+				Having(model.CountAll().Gt(1)).
+				Build().
+				StatementAndBindings()
+		asserts.Nil(err)
+		asserts.Equal(`SELECT ("t1"."c1" || $1) AS "c1", COUNT(*) FROM "t1" WHERE "t1"."c2" IS NOT NULL `+
+			`GROUP BY ("t1"."c1" || $2), TO_CHAR("t1"."c2", 'YYYY') HAVING COUNT(*) > $3`, stmt)
+		asserts.Equal([]interface{}{"-", "-", int64(1)}, bindings)
+	})
+
+	t.Run("CountAll", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		t1 := model.NewTable("t1")
+
+		stmt, _, err := NewSelectColumnBranchStep(root(dialect.MySQL), model.CountAll()).From(t1).GroupBy(model.CountAll()).Build().StatementAndBindings()
+		asserts.Nil(err)
+		asserts.Equal("SELECT COUNT(*) FROM `t1` GROUP BY COUNT(*)", stmt)
+	})
 }
 
 func TestSelectFromOrderBy_Accept(t *testing.T) {
@@ -300,3 +399,396 @@ func TestSelectFromLimitAndOffset_Accept(t *testing.T) {
 		asserts.Equal("SELECT `t1`.`c1` FROM `t1` LIMIT 10 OFFSET 1", stmt)
 	})
 }
+
+func TestSelectFromSeekAfter_Accept(t *testing.T) {
+	t1 := model.NewTable("t1")
+
+	c1 := model.NewInt64Column(t1, "c1")
+	c2 := model.NewTextColumn(t1, "c2")
+	c3 := model.NewBoolColumn(t1, "c3")
+
+	t.Run("RowValue", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		stmt, bindings, err :=
+			NewSelectColumnBranchStep(root(dialect.MySQL), c1, c2).From(t1).
+				Where(c3.Eq(true)).
+				SeekAfter([]*model.SortOrder{c2.Asc(), c1.Asc()}, "foo", int64(10)).
+				LimitAndOffset(20, 0).
+				Build().
+				StatementAndBindings()
+		asserts.Nil(err)
+		asserts.Equal("SELECT `t1`.`c1`, `t1`.`c2` FROM `t1` WHERE `t1`.`c3` = ? AND (`t1`.`c2`, `t1`.`c1`) > (?, ?) ORDER BY `t1`.`c2` ASC, `t1`.`c1` ASC LIMIT 20", stmt)
+		asserts.Equal([]interface{}{true, "foo", int64(10)}, bindings)
+	})
+
+	t.Run("RowValue_Postgres", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		stmt, _, err :=
+			NewSelectColumnBranchStep(root(dialect.Postgres), c1).From(t1).
+				Where().
+				SeekAfter([]*model.SortOrder{c2.Desc(), c1.Desc()}, "foo", int64(10)).
+				Build().
+				StatementAndBindings()
+		asserts.Nil(err)
+		asserts.Equal(`SELECT "t1"."c1" FROM "t1" WHERE ("t1"."c2", "t1"."c1") < ($1, $2) ORDER BY "t1"."c2" DESC, "t1"."c1" DESC`, stmt)
+	})
+
+	t.Run("SingleOrder", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		stmt, _, err :=
+			NewSelectColumnBranchStep(root(dialect.MySQL), c1).From(t1).
+				Where().
+				SeekAfter([]*model.SortOrder{c1.Desc()}, int64(10)).
+				Build().
+				StatementAndBindings()
+		asserts.Nil(err)
+		asserts.Equal("SELECT `t1`.`c1` FROM `t1` WHERE `t1`.`c1` < ? ORDER BY `t1`.`c1` DESC", stmt)
+	})
+
+	t.Run("MixedDirection", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		stmt, bindings, err :=
+			NewSelectColumnBranchStep(root(dialect.MySQL), c1).From(t1).
+				Where().
+				SeekAfter([]*model.SortOrder{c2.Desc(), c1.Asc()}, "foo", int64(10)).
+				Build().
+				StatementAndBindings()
+		asserts.Nil(err)
+		asserts.Equal("SELECT `t1`.`c1` FROM `t1` WHERE (`t1`.`c2` < ? OR (`t1`.`c2` = ? AND `t1`.`c1` > ?)) ORDER BY `t1`.`c2` DESC, `t1`.`c1` ASC", stmt)
+		asserts.Equal([]interface{}{"foo", "foo", int64(10)}, bindings)
+	})
+
+	t.Run("WithoutRowValue", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		stmt, _, err :=
+			NewSelectColumnBranchStep(&RootStep{dialect: &bracketDialect{dialect.Standard{DialectName: "bracket"}}}, c1).From(t1).
+				Where().
+				SeekAfter([]*model.SortOrder{c2.Asc(), c1.Asc()}, "foo", int64(10)).
+				Build().
+				StatementAndBindings()
+		asserts.Nil(err)
+		asserts.Equal("SELECT [t1].[c1] FROM [t1] WHERE ([t1].[c2] > @p1 OR ([t1].[c2] = @p2 AND [t1].[c1] > @p3)) ORDER BY [t1].[c2] ASC, [t1].[c1] ASC", stmt)
+	})
+
+	t.Run("AfterOrderBy", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		orders := []*model.SortOrder{c2.Asc(), c1.Asc()}
+
+		stmt, bindings, err :=
+			NewSelectColumnBranchStep(root(dialect.MySQL), c1).From(t1).
+				Where(c3.Eq(true)).
+				OrderBy(orders...).
+				SeekAfter("foo", int64(10)).
+				LimitAndOffset(20, 0).
+				Build().
+				StatementAndBindings()
+		asserts.Nil(err)
+		asserts.Equal("SELECT `t1`.`c1` FROM `t1` WHERE `t1`.`c3` = ? AND (`t1`.`c2`, `t1`.`c1`) > (?, ?) ORDER BY `t1`.`c2` ASC, `t1`.`c1` ASC LIMIT 20", stmt)
+		asserts.Equal([]interface{}{true, "foo", int64(10)}, bindings)
+	})
+
+	t.Run("AfterOrderBy_Postgres", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		orders := []*model.SortOrder{c2.Asc(), c1.Asc()}
+
+		stmt, bindings, err :=
+			NewSelectColumnBranchStep(root(dialect.Postgres), c1).From(t1).
+				Where(c3.Eq(true)).
+				OrderBy(orders...).
+				SeekAfter("foo", int64(10)).
+				Build().
+				StatementAndBindings()
+		asserts.Nil(err)
+		asserts.Equal(`SELECT "t1"."c1" FROM "t1" WHERE "t1"."c3" = $1 AND ("t1"."c2", "t1"."c1") > ($2, $3) ORDER BY "t1"."c2" ASC, "t1"."c1" ASC`, stmt)
+		asserts.Equal([]interface{}{true, "foo", int64(10)}, bindings)
+	})
+
+	t.Run("AfterOrderByWithoutWhere", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		stmt, _, err :=
+			NewSelectFromBranchStep(root(dialect.MySQL), t1).
+				OrderBy(c1.Asc()).
+				SeekAfter(int64(10)).
+				Build().
+				StatementAndBindings()
+		asserts.Nil(err)
+		asserts.Equal("SELECT * FROM `t1` WHERE `t1`.`c1` > ? ORDER BY `t1`.`c1` ASC", stmt)
+	})
+
+	t.Run("AfterOrderByValuesMismatch", func(t *testing.T) {
+		_, _, err := NewSelectFromBranchStep(root(dialect.MySQL), t1).OrderBy(c2.Asc(), c1.Asc()).SeekAfter("foo").Build().StatementAndBindings()
+		assert.True(t, errors.Is(err, ErrorSeekValuesMismatch))
+	})
+
+	t.Run("NoOrders", func(t *testing.T) {
+		_, _, err := NewSelectFromBranchStep(root(dialect.MySQL), t1).Where().SeekAfter(nil).Build().StatementAndBindings()
+		assert.True(t, errors.Is(err, ErrorSeekNoOrders))
+	})
+
+	t.Run("ValuesMismatch", func(t *testing.T) {
+		_, _, err := NewSelectFromBranchStep(root(dialect.MySQL), t1).Where().SeekAfter([]*model.SortOrder{c1.Asc()}).Build().StatementAndBindings()
+		assert.True(t, errors.Is(err, ErrorSeekValuesMismatch))
+	})
+}
+
+func TestSelectCompound_Accept(t *testing.T) {
+	t1 := model.NewTable("t1")
+	t1c1 := model.NewInt64Column(t1, "c1")
+	t1c2 := model.NewTextColumn(t1, "c2")
+
+	t2 := model.NewTable("t2")
+	t2c1 := model.NewInt64Column(t2, "c1")
+	t2c2 := model.NewTextColumn(t2, "c2")
+
+	t.Run("Union", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		stmt, bindings, err :=
+			NewSelectColumnBranchStep(root(dialect.Postgres), t1c1, t1c2).From(t1).
+				Where(t1c2.Eq("foo")).
+				Union(NewSelectColumnBranchStep(root(dialect.Postgres), t2c1, t2c2).From(t2).Where(t2c2.Eq("bar")).Build()).
+				UnionAll(NewSelectColumnBranchStep(root(dialect.Postgres), t2c1, t2c2).From(t2).Where(t2c2.Eq("baz")).Build()).
+				OrderBy(t1c2.Desc(), t1c1.Asc()).
+				LimitAndOffset(10, 20).
+				Build().
+				StatementAndBindings()
+		asserts.Nil(err)
+		asserts.Equal(`SELECT "t1"."c1", "t1"."c2" FROM "t1" WHERE "t1"."c2" = $1 `+
+			`UNION SELECT "t2"."c1", "t2"."c2" FROM "t2" WHERE "t2"."c2" = $2 `+
+			`UNION ALL SELECT "t2"."c1", "t2"."c2" FROM "t2" WHERE "t2"."c2" = $3 `+
+			`ORDER BY "c2" DESC, "c1" ASC LIMIT 10 OFFSET 20`, stmt)
+		asserts.Equal([]interface{}{"foo", "bar", "baz"}, bindings)
+	})
+
+	t.Run("IntersectAndExcept", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		stmt, _, err :=
+			NewSelectColumnBranchStep(root(dialect.Sqlite3), t1c1).From(t1).
+				Intersect(NewSelectColumnBranchStep(root(dialect.Sqlite3), t2c1).From(t2).Build()).
+				Except(NewSelectColumnBranchStep(root(dialect.Sqlite3), t2c2).From(t2).GroupBy(t2c2).Build()).
+				Build().
+				StatementAndBindings()
+		asserts.Nil(err)
+		asserts.Equal(`SELECT "t1"."c1" FROM "t1" INTERSECT SELECT "t2"."c1" FROM "t2" EXCEPT SELECT "t2"."c2" FROM "t2" GROUP BY "t2"."c2"`, stmt)
+	})
+
+	t.Run("NotSupported", func(t *testing.T) {
+		_, _, err :=
+			NewSelectColumnBranchStep(root(dialect.MySQL), t1c1).From(t1).
+				Intersect(NewSelectColumnBranchStep(root(dialect.MySQL), t2c1).From(t2).Build()).
+				Build().
+				StatementAndBindings()
+		assert.True(t, errors.Is(err, dialect.ErrorNotSupported))
+	})
+
+	t.Run("WithOrderBy", func(t *testing.T) {
+		_, _, err :=
+			NewSelectColumnBranchStep(root(dialect.MySQL), t1c1).From(t1).
+				Union(NewSelectColumnBranchStep(root(dialect.MySQL), t2c1).From(t2).OrderBy(t2c1.Asc()).Build()).
+				Build().
+				StatementAndBindings()
+		assert.True(t, errors.Is(err, ErrorCompoundWithOrderLimit))
+	})
+
+	t.Run("WithLimit", func(t *testing.T) {
+		_, _, err :=
+			NewSelectColumnBranchStep(root(dialect.MySQL), t1c1).From(t1).
+				Union(NewSelectColumnBranchStep(root(dialect.MySQL), t2c1).From(t2).LimitAndOffset(1, 0).Build()).
+				Build().
+				StatementAndBindings()
+		assert.True(t, errors.Is(err, ErrorCompoundWithOrderLimit))
+	})
+}
+
+func TestSelectFromHaving_Accept(t *testing.T) {
+	t1 := model.NewTable("t1")
+
+	c1 := model.NewInt64Column(t1, "c1")
+	c2 := model.NewTextColumn(t1, "c2")
+
+	t.Run("Having", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		stmt, bindings, err :=
+			NewSelectColumnBranchStep(root(dialect.Postgres), c2, model.Count(c1)).From(t1).
+				Where(c1.Gt(0)).
+				GroupBy(c2).
+				Having(model.Count(c1).Gt(5), model.Count(c1).LtOrEq(10)).
+				OrderBy(c2.Asc()).
+				LimitAndOffset(10, 0).
+				Build().
+				StatementAndBindings()
+		asserts.Nil(err)
+		asserts.Equal(`SELECT "t1"."c2", COUNT("t1"."c1") FROM "t1" WHERE "t1"."c1" > $1 GROUP BY "t1"."c2" HAVING (COUNT("t1"."c1") > $2 AND COUNT("t1"."c1") <= $3) ORDER BY "t1"."c2" ASC LIMIT 10`, stmt)
+		asserts.Equal([]interface{}{int64(0), int64(5), int64(10)}, bindings)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		stmt, _, err := NewSelectColumnBranchStep(root(dialect.MySQL), c2).From(t1).GroupBy(c2).Having().Build().StatementAndBindings()
+		asserts.Nil(err)
+		asserts.Equal("SELECT `t1`.`c2` FROM `t1` GROUP BY `t1`.`c2`", stmt)
+	})
+
+	t.Run("SeekAfter", func(t *testing.T) {
+		_, _, err :=
+			NewSelectColumnBranchStep(root(dialect.MySQL), c2).From(t1).
+				GroupBy(c2).
+				Having(model.Count(c1).Gt(5)).
+				OrderBy(c2.Asc()).
+				SeekAfter("foo").
+				Build().
+				StatementAndBindings()
+		assert.True(t, errors.Is(err, ErrorSeekWithGroupBy))
+	})
+
+	t.Run("NestedAggregate", func(t *testing.T) {
+		_, _, err :=
+			NewSelectColumnBranchStep(root(dialect.MySQL), c2).From(t1).
+				GroupBy(c2).
+				Having(model.Sum(c1.Max()).Gt(5)).
+				Build().
+				StatementAndBindings()
+		assert.True(t, errors.Is(err, model.ErrorNestedAggregate))
+	})
+}
+
+func TestSelectAggregate_Accept(t *testing.T) {
+	t1 := model.NewTable("t1")
+
+	c1 := model.NewInt64Column(t1, "c1")
+	c2 := model.NewTextColumn(t1, "c2")
+
+	t.Run("SelectOrderByHaving", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		stmt, bindings, err :=
+			NewSelectColumnBranchStep(root(dialect.Postgres), c2, c1.Sum().As("total"), c1.Max(), model.GroupConcat(c1).OrderBy(c1.Asc())).From(t1).
+				GroupBy(c2).
+				Having(c1.Max().Gt(10), model.CountAll().GtOrEq(2)).
+				OrderBy(c1.Sum().Desc(), c2.Asc()).
+				Build().
+				StatementAndBindings()
+		asserts.Nil(err)
+		asserts.Equal(`SELECT "t1"."c2", SUM("t1"."c1") AS "total", MAX("t1"."c1"), STRING_AGG(CAST("t1"."c1" AS TEXT), ',' ORDER BY "t1"."c1" ASC) `+
+			`FROM "t1" GROUP BY "t1"."c2" HAVING (MAX("t1"."c1") > $1 AND COUNT(*) >= $2) ORDER BY SUM("t1"."c1") DESC, "t1"."c2" ASC`, stmt)
+		asserts.Equal([]interface{}{int64(10), int64(2)}, bindings)
+	})
+
+	t.Run("GroupConcatNotSupported", func(t *testing.T) {
+		_, _, err :=
+			NewSelectColumnBranchStep(root(dialect.Sqlite3), model.GroupConcat(c2).OrderBy(c2.Asc())).From(t1).
+				Build().
+				StatementAndBindings()
+		assert.True(t, errors.Is(err, dialect.ErrorNotSupported))
+	})
+}
+
+func TestSelectWindow_Accept(t *testing.T) {
+	t1 := model.NewTable("t1")
+
+	c1 := model.NewInt64Column(t1, "c1")
+	c2 := model.NewTextColumn(t1, "c2")
+
+	asserts := assert.New(t)
+
+	rn := model.RowNumber().Over(model.PartitionBy(c2), model.OrderBy(c1.Desc()))
+	stmt, bindings, err :=
+		NewSelectColumnBranchStep(root(dialect.Postgres), c2, rn.As("rn"), model.Lag(c1, 1).Over(model.OrderBy(c1.Asc()))).From(t1).
+			Where(c1.Gt(1)).
+			OrderBy(rn.Asc()).
+			Build().
+			StatementAndBindings()
+	asserts.Nil(err)
+	asserts.Equal(`SELECT "t1"."c2", ROW_NUMBER() OVER (PARTITION BY "t1"."c2" ORDER BY "t1"."c1" DESC) AS "rn", LAG("t1"."c1", 1) OVER (ORDER BY "t1"."c1" ASC) `+
+		`FROM "t1" WHERE "t1"."c1" > $1 ORDER BY ROW_NUMBER() OVER (PARTITION BY "t1"."c2" ORDER BY "t1"."c1" DESC) ASC`, stmt)
+	asserts.Equal([]interface{}{int64(1)}, bindings)
+}
+
+func TestSelectExpression_Accept(t *testing.T) {
+	t1 := model.NewTable("t1")
+
+	c1 := model.NewInt64Column(t1, "c1")
+	c2 := model.NewTextColumn(t1, "c2")
+
+	asserts := assert.New(t)
+
+	label := model.CaseWhen(c1.Gt(10), "large").Else("small").End()
+	stmt, bindings, err :=
+		NewSelectColumnBranchStep(root(dialect.Sqlite3), c1, label.As("label"), model.Concat(c2, "!").As("c2")).From(t1).
+			Where(model.Lower(c2).NotEq("foo")).
+			OrderBy(model.IfNull(c1, 0).Desc(), c2.Asc()).
+			LimitAndOffset(10, 0).
+			Build().
+			StatementAndBindings()
+	asserts.Nil(err)
+	asserts.Equal(`SELECT "t1"."c1", CASE WHEN "t1"."c1" > ? THEN ? ELSE ? END AS "label", ("t1"."c2" || ?) AS "c2" `+
+		`FROM "t1" WHERE LOWER("t1"."c2") != ? ORDER BY COALESCE("t1"."c1", ?) DESC, "t1"."c2" ASC LIMIT 10`, stmt)
+	asserts.Equal([]interface{}{int64(10), "large", "small", "!", "foo", 0}, bindings)
+}
+
+func TestSelectExpressionCondition_Accept(t *testing.T) {
+	t1 := model.NewTable("t1")
+
+	c1 := model.NewInt64Column(t1, "c1")
+	c2 := model.NewTextColumn(t1, "c2")
+	c3 := model.NewTimeColumn(t1, "c3")
+
+	tests := []struct {
+		name     string
+		dialect  string
+		format   string
+		expected string
+	}{
+		{
+			name:    "MySQL",
+			dialect: dialect.MySQL,
+			format:  "%Y",
+			expected: "SELECT `t1`.`c1` FROM `t1` " +
+				"WHERE (CONCAT(`t1`.`c2`, ?) = ? AND DATE_FORMAT(`t1`.`c3`, '%Y') = ? AND CASE WHEN CONCAT(`t1`.`c2`, ?) = ? THEN ? ELSE ? END = ?)",
+		},
+		{
+			name:    "Postgres",
+			dialect: dialect.Postgres,
+			format:  "YYYY",
+			expected: `SELECT "t1"."c1" FROM "t1" ` +
+				`WHERE (("t1"."c2" || $1) = $2 AND TO_CHAR("t1"."c3", 'YYYY') = $3 AND CASE WHEN ("t1"."c2" || $4) = $5 THEN $6 ELSE $7 END = $8)`,
+		},
+		{
+			name:    "Sqlite3",
+			dialect: dialect.Sqlite3,
+			format:  "%Y",
+			expected: `SELECT "t1"."c1" FROM "t1" ` +
+				`WHERE (("t1"."c2" || ?) = ? AND STRFTIME('%Y', "t1"."c3") = ? AND CASE WHEN ("t1"."c2" || ?) = ? THEN ? ELSE ? END = ?)`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stmt, bindings, err :=
+				NewSelectColumnBranchStep(root(test.dialect), c1).
+					From(t1).
+					Where(
+						model.Concat(c2, "!").Eq("a!"),
+						model.DateFormat(c3, test.format).Eq("2020"),
+						model.CaseWhen(model.Concat(c2, "?").Eq("b?"), 1).Else(0).End().Eq(1)).
+					Build().
+					StatementAndBindings()
+
+			asserts := assert.New(t)
+			asserts.Nil(err)
+			asserts.Equal(test.expected, stmt)
+			asserts.Equal([]interface{}{"!", "a!", "2020", "?", "b?", 1, 0, 1}, bindings)
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package statement
 
 import (
-	"fmt"
 	"github.com/tmarcus87/sqlike/model"
 )
 
@@ -15,6 +14,10 @@ func (s *TruncateStep) Parent() StatementAcceptor {
 }
 
 func (s *TruncateStep) Accept(stmt *StatementImpl) error {
-	stmt.Statement += fmt.Sprintf("TRUNCATE %s", s.table.SQLikeTableExpr())
+	expr, err := tableExpr(stmt, s.table)
+	if err != nil {
+		return err
+	}
+	stmt.Statement += stmt.Dialect.Truncate(expr)
 	return nil
 }
@@ -0,0 +1,38 @@
+package statement
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/tmarcus87/sqlike/dialect"
+	"github.com/tmarcus87/sqlike/model"
+	"testing"
+)
+
+func TestTruncateStep_Accept(t *testing.T) {
+	tests := []struct {
+		dialect string
+		expect  string
+	}{
+		{
+			dialect: dialect.MySQL,
+			expect:  "TRUNCATE `t1`",
+		},
+		{
+			dialect: dialect.Postgres,
+			expect:  `TRUNCATE "t1"`,
+		},
+		{
+			dialect: dialect.Sqlite3,
+			expect:  `DELETE FROM "t1"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.dialect, func(t *testing.T) {
+			stmt, _, err := NewTruncateBranchStep(root(test.dialect), model.NewTable("t1")).Build().StatementAndBindings()
+
+			asserts := assert.New(t)
+			asserts.Nil(err)
+			asserts.Equal(test.expect, stmt)
+		})
+	}
+}
@@ -20,7 +20,13 @@ func (s *UpdateStep) Parent() StatementAcceptor {
 }
 
 func (s *UpdateStep) Accept(stmt *StatementImpl) error {
-	stmt.Statement += fmt.Sprintf("UPDATE `%s` ", s.table.SQLikeTableName())
+	// 条件句がエイリアスで修飾されるため、エイリアスも出力する
+	expr, err := tableExpr(stmt, s.table)
+	if err != nil {
+		return err
+	}
+	stmt.Statement += fmt.Sprintf("UPDATE %s ", expr)
+	stmt.State[StateWriteStmt] = true
 	return nil
 }
 
@@ -40,12 +46,33 @@ func (s *UpdateSetStep) Accept(stmt *StatementImpl) error {
 	} else {
 		stmt.Statement += "SET "
 	}
-	stmt.Statement += fmt.Sprintf("`%s` = ? ", s.columnValue.ColumnName())
-	stmt.Bindings = append(stmt.Bindings, s.columnValue.ColumnValue())
+	if err := applyAssignment(stmt, s.columnValue); err != nil {
+		return err
+	}
 	stmt.State[StateUpdateStmtSet] = true
 	return nil
 }
 
+// applyAssignment appends the assignment like "`c1` = ?", the expression is rendered as it is like "`c1` = `t1`.`c1` + ?"
+func applyAssignment(stmt *StatementImpl, columnValue model.ColumnValue) error {
+	column := stmt.Dialect.QuoteIdentifier(columnValue.ColumnName())
+	// 複数テーブルの更新ではカラム名が曖昧にならないようにテーブル名で修飾する
+	if _, ok := stmt.State[StateWriteStmtJoin]; ok && columnValue.Table() != nil {
+		column = stmt.Dialect.QuoteIdentifier(columnValue.Table().SQLikeAliasOrName()) + "." + column
+	}
+
+	if e, ok := columnValue.ColumnValue().(model.Expression); ok {
+		expr, err := e.SQLikeExpr(stmt.Dialect, &stmt.Bindings)
+		if err != nil {
+			return err
+		}
+		stmt.Statement += fmt.Sprintf("%s = %s ", column, expr)
+		return nil
+	}
+	stmt.Statement += fmt.Sprintf("%s = %s ", column, bind(stmt, columnValue.ColumnValue()))
+	return nil
+}
+
 type UpdateSetRecordStep struct {
 	parent StatementAcceptor
 	record *model.Record
@@ -61,62 +88,59 @@ func (s UpdateSetRecordStep) Accept(stmt *StatementImpl) error {
 
 func ApplySetStatement(stmt *StatementImpl, record *model.Record) error {
 	stmt.Statement += "SET "
+	return ApplyAssignments(stmt, record)
+}
 
+// ApplyAssignments appends the assignments of the record columns like "`c1` = ?, `c2` = ?"
+func ApplyAssignments(stmt *StatementImpl, record *model.Record) error {
 	setColumns := make([]string, 0)
 	setBindings := make([]interface{}, 0)
 
-	fvm, err := getColumnName2FieldValueMap(record.Value)
+	v, m, err := structValue(record.Value)
 	if err != nil {
 		return err
 	}
 
 	if len(record.Only) > 0 {
 		// 指定されたカラムのみ変更する
+		names := make([]string, 0, len(record.Only))
 		for _, onlyColumn := range record.Only {
-			fv, ok := fvm[onlyColumn.ColumnName()]
-			if !ok {
-				return fmt.Errorf("struct field for '%s' is not found", onlyColumn.ColumnName())
-			}
-			setColumns = append(setColumns, onlyColumn.ColumnName())
-			setBindings = append(setBindings, fv.Interface())
+			names = append(names, onlyColumn.ColumnName())
 		}
-	} else if len(record.Skip) > 0 {
-		// 指定されたカラム以外を変更する
-
+		values, err := fieldValues(record.Value, names)
+		if err != nil {
+			return err
+		}
+		setColumns = names
+		setBindings = values
+	} else {
+		// 指定されたカラム以外を構造体のフィールド順に変更する。指定がなければすべてのカラムを変更する
+		// nilの埋め込みのポインタのカラムは変更しない
 		skipColumnNames := make(map[string]struct{})
 		for _, skipColumn := range record.Skip {
 			skipColumnNames[skipColumn.ColumnName()] = struct{}{}
 		}
 
-		for colName, fv := range fvm {
-			if _, ok := skipColumnNames[colName]; !ok {
-				setColumns = append(setColumns, colName)
+		for _, f := range m.fields {
+			if _, ok := skipColumnNames[f.name]; ok {
+				continue
+			}
+			if _, err := m.field(f.name); err != nil {
+				return err
+			}
+			if fv, ok := fieldByIndex(v, f.index); ok {
+				setColumns = append(setColumns, f.name)
 				setBindings = append(setBindings, fv.Interface())
 			}
 		}
-
-	} else {
-		// すべてのカラムを変更する
-		fields, err := getOrderedColumnName(record.Value)
-		if err != nil {
-			return err
-		}
-
-		for _, field := range fields {
-			fv := fvm[field]
-			setColumns = append(setColumns, field)
-			setBindings = append(setBindings, fv.Interface())
-		}
 	}
 
 	setStmt := make([]string, 0)
-	for _, column := range setColumns {
-		setStmt = append(setStmt, fmt.Sprintf("`%s` = ?", column))
+	for i, column := range setColumns {
+		setStmt = append(setStmt, fmt.Sprintf("%s = %s", stmt.Dialect.QuoteIdentifier(column), bind(stmt, setBindings[i])))
 	}
 
 	stmt.Statement += strings.Join(setStmt, ", ") + " "
-	stmt.Bindings = append(stmt.Bindings, setBindings...)
 
 	return nil
-
 }
@@ -1,6 +1,7 @@
 package statement
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/tmarcus87/sqlike/dialect"
 	"github.com/tmarcus87/sqlike/model"
@@ -22,6 +23,7 @@ func TestUpdateSetStep_Accept(t *testing.T) {
 		stmt, bindings, err :=
 			NewUpdateBranchStep(root(dialect.MySQL), t1).
 				SetValue(c1.Value(1)).
+				AllRows().
 				Build().
 				StatementAndBindings()
 
@@ -37,6 +39,7 @@ func TestUpdateSetStep_Accept(t *testing.T) {
 			NewUpdateBranchStep(root(dialect.MySQL), t1).
 				SetValue(c1.Value(1)).
 				SetValue(c2.Value(2)).
+				AllRows().
 				Build().
 				StatementAndBindings()
 
@@ -48,6 +51,17 @@ func TestUpdateSetStep_Accept(t *testing.T) {
 		asserts.Equal(int32(2), bindings[1])
 	})
 
+	t.Run("WithoutWhere", func(t *testing.T) {
+		_, _, err :=
+			NewUpdateBranchStep(root(dialect.MySQL), t1).
+				SetValue(c1.Value(1)).
+				Build().
+				StatementAndBindings()
+
+		asserts := assert.New(t)
+		asserts.True(errors.Is(err, ErrorWriteWithoutWhere))
+	})
+
 	t.Run("WithWhere", func(t *testing.T) {
 		stmt, bindings, err :=
 			NewUpdateBranchStep(root(dialect.MySQL), t1).
@@ -136,4 +150,186 @@ func TestUpdateSetRecordStep_Accept(t *testing.T) {
 		asserts.Equal(true, bindings[2])
 
 	})
+
+	t.Run("WithNilPointerEmbedded", func(t *testing.T) {
+		type Base struct {
+			C1 int
+		}
+		type PtrValue struct {
+			*Base
+			Column2 int `sqlike:"c2"`
+		}
+
+		stmt, bindings, err :=
+			NewUpdateBranchStep(root(dialect.MySQL), t1).
+				SetRecord(&model.Record{Value: &PtrValue{Column2: 2}}).
+				Where(c1.Eq(true)).
+				Build().
+				StatementAndBindings()
+		asserts := assert.New(t)
+		asserts.Nil(err)
+		asserts.Equal("UPDATE `t1` SET `c2` = ? WHERE `t1`.`c1` = ?", stmt)
+		asserts.Equal([]interface{}{2, true}, bindings)
+	})
+}
+
+func TestUpdateSetStep_Expression(t *testing.T) {
+	t1 := model.NewTable("t1")
+
+	c1 := model.NewInt32Column(t1, "c1")
+	c2 := model.NewTextColumn(t1, "c2")
+
+	stmt, bindings, err :=
+		NewUpdateBranchStep(root(dialect.Postgres), t1).
+			SetValue(model.ExprValue(c2, model.Concat(model.Lower(c2), "-", c1))).
+			SetValue(c1.Value(1)).
+			Where(c1.Eq(3)).
+			Build().
+			StatementAndBindings()
+
+	asserts := assert.New(t)
+	asserts.Nil(err)
+	asserts.Equal(`UPDATE "t1" SET "c2" = (LOWER("t1"."c2") || $1 || "t1"."c1"), "c1" = $2 WHERE "t1"."c1" = $3`, stmt)
+	asserts.Equal([]interface{}{"-", int32(1), int32(3)}, bindings)
+}
+
+func TestUpdateSetStep_SetExpr(t *testing.T) {
+	t1 := model.NewTable("t1")
+
+	c1 := model.NewInt32Column(t1, "c1")
+	c2 := model.NewTimeColumn(t1, "c2")
+
+	stmt, bindings, err :=
+		NewUpdateBranchStep(root(dialect.MySQL), t1).
+			SetExpr(c1, model.ColumnOf(c1).Plus(1)).
+			SetExpr(c2, model.Now()).
+			Where(c1.Lt(10)).
+			Build().
+			StatementAndBindings()
+
+	asserts := assert.New(t)
+	asserts.Nil(err)
+	asserts.Equal("UPDATE `t1` SET `c1` = (`t1`.`c1` + ?), `c2` = CURRENT_TIMESTAMP WHERE `t1`.`c1` < ?", stmt)
+	asserts.Equal([]interface{}{1, int32(10)}, bindings)
+}
+
+func TestUpdateJoin_Accept(t *testing.T) {
+	t1 := model.NewTable("t1").As("a")
+	t1c1 := model.NewInt32Column(t1, "c1")
+	t1c2 := model.NewTextColumn(t1, "c2")
+
+	t2 := model.NewTable("t2")
+	t2c1 := model.NewInt32Column(t2, "c1")
+	t2c2 := model.NewTextColumn(t2, "c2")
+
+	t.Run("Join", func(t *testing.T) {
+		stmt, bindings, err :=
+			NewUpdateBranchStep(root(dialect.MySQL), t1).
+				InnerJoin(t2, t2c1.EqCol(t1c1), t2c2.Eq("foo")).
+				SetExpr(t1c2, model.ColumnOf(t2c2)).
+				SetValue(t1c1.Value(1)).
+				Where(t2c1.Gt(10)).
+				Build().
+				StatementAndBindings()
+
+		asserts := assert.New(t)
+		asserts.Nil(err)
+		asserts.Equal("UPDATE `t1` AS `a` INNER JOIN `t2` ON (`t2`.`c1` = `a`.`c1` AND `t2`.`c2` = ?) "+
+			"SET `a`.`c2` = `t2`.`c2`, `a`.`c1` = ? WHERE `t2`.`c1` > ?", stmt)
+		asserts.Equal([]interface{}{"foo", int32(1), int32(10)}, bindings)
+	})
+
+	t.Run("NotSupported", func(t *testing.T) {
+		_, _, err :=
+			NewUpdateBranchStep(root(dialect.Postgres), t1).
+				LeftOuterJoin(t2, t2c1.EqCol(t1c1)).
+				SetExpr(t1c2, model.ColumnOf(t2c2)).
+				Build().
+				StatementAndBindings()
+		assert.True(t, errors.Is(err, dialect.ErrorNotSupported))
+	})
+
+	t.Run("Alias", func(t *testing.T) {
+		stmt, _, err :=
+			NewUpdateBranchStep(root(dialect.Postgres), t1).
+				SetValue(t1c2.Value("bar")).
+				Where(t1c1.Eq(1)).
+				Build().
+				StatementAndBindings()
+
+		asserts := assert.New(t)
+		asserts.Nil(err)
+		asserts.Equal(`UPDATE "t1" AS "a" SET "c2" = $1 WHERE "a"."c1" = $2`, stmt)
+	})
+}
+
+func TestUpdateOrderByLimit_Accept(t *testing.T) {
+	t1 := model.NewTable("t1")
+	c1 := model.NewInt32Column(t1, "c1")
+	c2 := model.NewTimeColumn(t1, "c2")
+
+	t.Run("OrderByLimit", func(t *testing.T) {
+		stmt, bindings, err :=
+			NewUpdateBranchStep(root(dialect.MySQL), t1).
+				SetValue(c1.Value(0)).
+				Where(c1.Gt(1)).
+				OrderBy(c2.Asc()).
+				Limit(100).
+				Build().
+				StatementAndBindings()
+
+		asserts := assert.New(t)
+		asserts.Nil(err)
+		asserts.Equal("UPDATE `t1` SET `c1` = ? WHERE `t1`.`c1` > ? ORDER BY `t1`.`c2` ASC LIMIT 100", stmt)
+		asserts.Equal([]interface{}{int32(0), int32(1)}, bindings)
+	})
+
+	t.Run("NotSupported", func(t *testing.T) {
+		_, _, err :=
+			NewUpdateBranchStep(root(dialect.Sqlite3), t1).
+				SetValue(c1.Value(0)).
+				Limit(100).
+				Build().
+				StatementAndBindings()
+		assert.True(t, errors.Is(err, dialect.ErrorNotSupported))
+	})
+}
+
+func TestUpdateReturning_Accept(t *testing.T) {
+	t1 := model.NewTable("t1")
+	id := model.NewInt64Column(t1, "id")
+	c1 := model.NewInt32Column(t1, "c1")
+
+	stmt, bindings, err :=
+		NewUpdateBranchStep(root(dialect.Postgres), t1).
+			SetValue(c1.Value(1)).
+			Where(c1.Gt(0)).
+			Returning(id, c1).
+			Build().
+			StatementAndBindings()
+
+	asserts := assert.New(t)
+	asserts.Nil(err)
+	asserts.Equal(`UPDATE "t1" SET "c1" = $1 WHERE "t1"."c1" > $2 RETURNING "id", "c1"`, stmt)
+	asserts.Equal([]interface{}{int32(1), int32(0)}, bindings)
+
+	// Asはカラムを書き換えるため、別のカラムを使う
+	stmt, _, err =
+		NewUpdateBranchStep(root(dialect.Sqlite3), t1).
+			SetValue(c1.Value(1)).
+			Where(c1.Gt(0)).
+			Returning(model.NewInt64Column(t1, "id").As("updated_id")).
+			Build().
+			StatementAndBindings()
+	asserts.Nil(err)
+	asserts.Equal(`UPDATE "t1" SET "c1" = ? WHERE "t1"."c1" > ? RETURNING "id" AS "updated_id"`, stmt)
+
+	_, _, err =
+		NewUpdateBranchStep(root(dialect.MySQL), t1).
+			SetValue(c1.Value(1)).
+			AllRows().
+			Returning(id).
+			Build().
+			StatementAndBindings()
+	asserts.True(errors.Is(err, dialect.ErrorNotSupported))
 }
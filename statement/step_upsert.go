@@ -0,0 +1,108 @@
+package statement
+
+import (
+	"errors"
+	"fmt"
+	"github.com/tmarcus87/sqlike/model"
+	"strings"
+)
+
+var (
+	ErrorNoUpdateColumns = errors.New("no columns to update")
+)
+
+// UpsertStep inserts the records and updates the conflicted rows by the values proposed for insertion
+type UpsertStep struct {
+	parent  StatementAcceptor
+	records []*model.Record
+	keys    []model.Column
+	columns []model.Column
+
+	// exceptColumns trueの場合は挿入するカラムのうち、keysとcolumns以外を更新する
+	exceptColumns bool
+}
+
+func (s *UpsertStep) Parent() StatementAcceptor {
+	return s.parent
+}
+
+func (s *UpsertStep) Accept(stmt *StatementImpl) error {
+	inserted, err := applyInsertRecords(stmt, s.records)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0)
+	for _, key := range s.keys {
+		keys = append(keys, stmt.Dialect.QuoteIdentifier(key.ColumnName()))
+	}
+	clause, err := stmt.Dialect.OnConflictUpdate(keys...)
+	if err != nil {
+		return err
+	}
+
+	columns, err := s.updateColumns(inserted)
+	if err != nil {
+		return err
+	}
+
+	assignments := make([]string, 0)
+	for _, column := range columns {
+		col := stmt.Dialect.QuoteIdentifier(column)
+		value, err := stmt.Dialect.InsertedValue(col)
+		if err != nil {
+			return err
+		}
+		assignments = append(assignments, fmt.Sprintf("%s = %s", col, value))
+	}
+
+	stmt.Statement += fmt.Sprintf("%s %s ", clause, strings.Join(assignments, ", "))
+	return nil
+}
+
+// updateColumns 挿入するカラムのうち更新するカラムを返す
+func (s *UpsertStep) updateColumns(inserted []string) ([]string, error) {
+	insertedMap := make(map[string]struct{})
+	for _, column := range inserted {
+		insertedMap[column] = struct{}{}
+	}
+
+	columns := make([]string, 0)
+	if !s.exceptColumns {
+		for _, column := range s.columns {
+			// 挿入しないカラムは提案された値を参照できない
+			if _, ok := insertedMap[column.ColumnName()]; !ok {
+				return nil, fmt.Errorf("column '%s' to update is not inserted : %w", column.ColumnName(), ErrorNoColumnInfo)
+			}
+			columns = append(columns, column.ColumnName())
+		}
+	} else {
+		excepts := make(map[string]struct{})
+		for _, key := range s.keys {
+			excepts[key.ColumnName()] = struct{}{}
+		}
+		for _, column := range s.columns {
+			excepts[column.ColumnName()] = struct{}{}
+		}
+		for _, column := range inserted {
+			if _, ok := excepts[column]; !ok {
+				columns = append(columns, column)
+			}
+		}
+	}
+
+	if len(columns) == 0 {
+		return nil, ErrorNoUpdateColumns
+	}
+	return columns, nil
+}
+
+func (s *UpsertStep) numOfRows() int {
+	return len(s.records)
+}
+
+func (s *UpsertStep) chunk(from, to int) StatementAcceptor {
+	c := *s
+	c.records = s.records[from:to]
+	return &c
+}
@@ -0,0 +1,133 @@
+package statement
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/tmarcus87/sqlike/dialect"
+	"github.com/tmarcus87/sqlike/model"
+	"testing"
+)
+
+func TestUpsertStep_Accept(t *testing.T) {
+	type T1 struct {
+		Id int64  `sqlike:"id"`
+		C1 int32  `sqlike:"c1"`
+		C2 string `sqlike:"c2"`
+	}
+
+	t1 := model.NewTable("t1")
+	id := model.NewInt64Column(t1, "id")
+	c1 := model.NewInt32Column(t1, "c1")
+	c2 := model.NewTextColumn(t1, "c2")
+
+	records := []*model.Record{
+		{Value: &T1{Id: 1, C1: 10, C2: "a"}},
+		{Value: &T1{Id: 2, C1: 20, C2: "b"}},
+	}
+
+	tests := []struct {
+		name     string
+		dialect  string
+		expected string
+	}{
+		{
+			name:     "MySQL",
+			dialect:  dialect.MySQL,
+			expected: "INSERT INTO `t1` (`id`, `c1`, `c2`) VALUES (?, ?, ?), (?, ?, ?) ON DUPLICATE KEY UPDATE `c1` = VALUES(`c1`), `c2` = VALUES(`c2`)",
+		},
+		{
+			name:     "Postgres",
+			dialect:  dialect.Postgres,
+			expected: `INSERT INTO "t1" ("id", "c1", "c2") VALUES ($1, $2, $3), ($4, $5, $6) ON CONFLICT ("id") DO UPDATE SET "c1" = excluded."c1", "c2" = excluded."c2"`,
+		},
+		{
+			name:     "Sqlite3",
+			dialect:  dialect.Sqlite3,
+			expected: `INSERT INTO "t1" ("id", "c1", "c2") VALUES (?, ?, ?), (?, ?, ?) ON CONFLICT ("id") DO UPDATE SET "c1" = excluded."c1", "c2" = excluded."c2"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stmt, bindings, err :=
+				NewUpsertBranchStep(root(test.dialect), t1).
+					Records(records...).
+					OnConflict(id).
+					Update(c1, c2).
+					Build().
+					StatementAndBindings()
+
+			asserts := assert.New(t)
+			asserts.Nil(err)
+			asserts.Equal(test.expected, stmt)
+			asserts.Equal([]interface{}{int64(1), int32(10), "a", int64(2), int32(20), "b"}, bindings)
+		})
+	}
+
+	t.Run("UpdateAllExcept", func(t *testing.T) {
+		stmt, _, err :=
+			NewUpsertBranchStep(root(dialect.Postgres), t1).
+				Records(records...).
+				OnConflict(id).
+				UpdateAllExcept(c2).
+				Build().
+				StatementAndBindings()
+
+		asserts := assert.New(t)
+		asserts.Nil(err)
+		asserts.Equal(`INSERT INTO "t1" ("id", "c1", "c2") VALUES ($1, $2, $3), ($4, $5, $6) ON CONFLICT ("id") DO UPDATE SET "c1" = excluded."c1"`, stmt)
+
+		_, _, err =
+			NewUpsertBranchStep(root(dialect.Postgres), t1).
+				Records(records...).
+				OnConflict(id).
+				UpdateAllExcept(c1, c2).
+				Build().
+				StatementAndBindings()
+		asserts.True(errors.Is(err, ErrorNoUpdateColumns))
+	})
+
+	t.Run("NotInserted", func(t *testing.T) {
+		_, _, err :=
+			NewUpsertBranchStep(root(dialect.MySQL), t1).
+				Records(&model.Record{Value: &T1{Id: 1}, Only: []model.Column{id, c1}}).
+				OnConflict(id).
+				Update(c2).
+				Build().
+				StatementAndBindings()
+
+		asserts := assert.New(t)
+		asserts.True(errors.Is(err, ErrorNoColumnInfo))
+	})
+
+	t.Run("NoConflictTarget", func(t *testing.T) {
+		_, _, err :=
+			NewUpsertBranchStep(root(dialect.Postgres), t1).
+				Records(records...).
+				OnConflict().
+				Update(c1).
+				Build().
+				StatementAndBindings()
+
+		asserts := assert.New(t)
+		asserts.True(errors.Is(err, dialect.ErrorNotSupported))
+	})
+
+	t.Run("Chunked", func(t *testing.T) {
+		stmts, _, err :=
+			NewUpsertBranchStep(root(dialect.MySQL), t1).
+				Records(records...).
+				OnConflict(id).
+				Update(c1).
+				Chunked(1).
+				Build().
+				StatementsAndBindings()
+
+		asserts := assert.New(t)
+		asserts.Nil(err)
+		asserts.Equal([]string{
+			"INSERT INTO `t1` (`id`, `c1`, `c2`) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE `c1` = VALUES(`c1`)",
+			"INSERT INTO `t1` (`id`, `c1`, `c2`) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE `c1` = VALUES(`c1`)",
+		}, stmts)
+	})
+}
@@ -0,0 +1,55 @@
+package statement
+
+import (
+	"fmt"
+	"github.com/tmarcus87/sqlike/model"
+	"strings"
+)
+
+// commonTableExpr WITH句で定義する共通テーブル式
+type commonTableExpr struct {
+	name      string
+	columns   []string
+	query     model.SubQuery
+	recursive bool
+}
+
+type WithStep struct {
+	parent StatementAcceptor
+	ctes   []*commonTableExpr
+}
+
+func (s *WithStep) Parent() StatementAcceptor {
+	return s.parent
+}
+
+func (s *WithStep) Accept(stmt *StatementImpl) error {
+	// RECURSIVEはWITHの直後に一度だけ指定する
+	var recursive bool
+	exprs := make([]string, 0)
+	for _, cte := range s.ctes {
+		query, err := cte.query.SQLikeSubQuery(stmt.Dialect, &stmt.Bindings)
+		if err != nil {
+			return fmt.Errorf("failed to build common table expression(%s) : %w", cte.name, err)
+		}
+
+		name := stmt.Dialect.QuoteIdentifier(cte.name)
+		if len(cte.columns) > 0 {
+			cols := make([]string, 0)
+			for _, column := range cte.columns {
+				cols = append(cols, stmt.Dialect.QuoteIdentifier(column))
+			}
+			name += fmt.Sprintf("(%s)", strings.Join(cols, ", "))
+		}
+		exprs = append(exprs, fmt.Sprintf("%s AS (%s)", name, query))
+		recursive = recursive || cte.recursive
+	}
+
+	if recursive {
+		stmt.Statement += "WITH RECURSIVE "
+	} else {
+		stmt.Statement += "WITH "
+	}
+	stmt.Statement += strings.Join(exprs, ", ") + " "
+	return nil
+}
@@ -0,0 +1,73 @@
+package statement
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/tmarcus87/sqlike/dialect"
+	"github.com/tmarcus87/sqlike/model"
+	"testing"
+)
+
+func TestWith_Accept(t *testing.T) {
+	t1 := model.NewTable("t1")
+	t1c1 := model.NewInt64Column(t1, "c1")
+	t1c2 := model.NewTextColumn(t1, "c2")
+
+	cte := model.NewTable("cte")
+	ctec1 := model.NewInt64Column(cte, "c1")
+
+	t.Run("Select", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		with := NewWithBranchStep(root(dialect.Postgres), "cte", NewSelectColumnBranchStep(root(dialect.Postgres), t1c1).From(t1).Where(t1c2.Eq("foo")).Build())
+		stmt, bindings, err :=
+			with.SelectFrom(with.Table()).Where(ctec1.Gt(10)).Build().StatementAndBindings()
+		asserts.Nil(err)
+		asserts.Equal(`WITH "cte" AS (SELECT "t1"."c1" FROM "t1" WHERE "t1"."c2" = $1) SELECT * FROM "cte" WHERE "cte"."c1" > $2`, stmt)
+		asserts.Equal([]interface{}{"foo", int64(10)}, bindings)
+	})
+
+	t.Run("Multiple", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		with := NewWithBranchStep(root(dialect.MySQL), "c1", NewSelectFromBranchStep(root(dialect.MySQL), t1).Where(t1c1.Eq(1)).Build()).
+			WithRecursive("cte", NewSelectFromBranchStep(root(dialect.MySQL), t1).Where(t1c1.Eq(2)).
+				UnionAll(NewSelectFromBranchStep(root(dialect.MySQL), cte).Build()).
+				Build(), "c1", "c2")
+		stmt, bindings, err :=
+			with.Select(ctec1).From(cte).Build().StatementAndBindings()
+		asserts.Nil(err)
+		asserts.Equal("WITH RECURSIVE `c1` AS (SELECT * FROM `t1` WHERE `t1`.`c1` = ?), "+
+			"`cte`(`c1`, `c2`) AS (SELECT * FROM `t1` WHERE `t1`.`c1` = ? UNION ALL SELECT * FROM `cte`) "+
+			"SELECT `cte`.`c1` FROM `cte`", stmt)
+		asserts.Equal([]interface{}{int64(1), int64(2)}, bindings)
+	})
+
+	t.Run("Columns", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		with := NewWithBranchStep(root(dialect.Sqlite3), "cte", NewSelectFromBranchStep(root(dialect.Sqlite3), t1).Build(), "c1", `c"2`)
+		stmt, _, err :=
+			with.SelectFrom(with.Table()).Build().StatementAndBindings()
+		asserts.Nil(err)
+		asserts.Equal(`WITH "cte"("c1", "c""2") AS (SELECT * FROM "t1") SELECT * FROM "cte"`, stmt)
+	})
+
+	t.Run("UpdateAndDelete", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		with := NewWithBranchStep(root(dialect.Postgres), "cte", NewSelectColumnBranchStep(root(dialect.Postgres), t1c1).From(t1).Where(t1c2.Eq("foo")).Build())
+		sq := NewSelectColumnBranchStep(root(dialect.Postgres), ctec1).From(cte).Build()
+
+		stmt, bindings, err :=
+			with.Update(t1).SetValue(t1c2.Value("bar")).Where(t1c1.InSubQuery(sq)).Build().StatementAndBindings()
+		asserts.Nil(err)
+		asserts.Equal(`WITH "cte" AS (SELECT "t1"."c1" FROM "t1" WHERE "t1"."c2" = $1) UPDATE "t1" SET "c2" = $2 WHERE "t1"."c1" IN (SELECT "cte"."c1" FROM "cte")`, stmt)
+		asserts.Equal([]interface{}{"foo", "bar"}, bindings)
+
+		stmt, bindings, err =
+			with.DeleteFrom(t1).Where(t1c1.InSubQuery(sq)).Build().StatementAndBindings()
+		asserts.Nil(err)
+		asserts.Equal(`WITH "cte" AS (SELECT "t1"."c1" FROM "t1" WHERE "t1"."c2" = $1) DELETE FROM "t1" WHERE "t1"."c1" IN (SELECT "cte"."c1" FROM "cte")`, stmt)
+		asserts.Equal([]interface{}{"foo"}, bindings)
+	})
+}
@@ -0,0 +1,143 @@
+package statement
+
+import (
+	"errors"
+	"fmt"
+	"github.com/tmarcus87/sqlike/dialect"
+	"github.com/tmarcus87/sqlike/model"
+	"strings"
+)
+
+const (
+	StateWriteStmt          = "WRITE_STMT"
+	StateWriteStmtJoin      = "WRITE_STMT_JOIN"
+	StateWriteStmtAllRows   = "WRITE_STMT_ALL_ROWS"
+	StateWriteStmtReturning = "WRITE_STMT_RETURNING"
+)
+
+var (
+	// ErrorWriteWithoutWhere is returned when UPDATE or DELETE has no condition and AllRows is not called
+	ErrorWriteWithoutWhere = errors.New("update or delete without where is not allowed, use AllRows to write all rows")
+)
+
+// checkWriteWithoutWhere 条件のないUPDATEとDELETEは、AllRowsが指定されているか許可されている場合のみ組み立てる
+func checkWriteWithoutWhere(stmt *StatementImpl, root StatementAcceptor) error {
+	if _, ok := stmt.State[StateWriteStmt]; !ok {
+		return nil
+	}
+	if _, ok := stmt.State[StateWhereStmtHasCondition]; ok {
+		return nil
+	}
+	if _, ok := stmt.State[StateWriteStmtAllRows]; ok {
+		return nil
+	}
+	if r, ok := root.(*RootStep); ok && r.allowWriteWithoutWhere {
+		return nil
+	}
+	return ErrorWriteWithoutWhere
+}
+
+// AllRowsStep allows UPDATE and DELETE to write all rows without WHERE
+type AllRowsStep struct {
+	parent StatementAcceptor
+}
+
+func (s *AllRowsStep) Parent() StatementAcceptor {
+	return s.parent
+}
+
+func (s *AllRowsStep) Accept(stmt *StatementImpl) error {
+	stmt.State[StateWriteStmtAllRows] = true
+	return nil
+}
+
+// WriteJoinStep joins the table to UPDATE and DELETE
+type WriteJoinStep struct {
+	parent     StatementAcceptor
+	table      model.Table
+	conditions []model.Condition
+	joinType   string
+}
+
+func (s *WriteJoinStep) Parent() StatementAcceptor {
+	return s.parent
+}
+
+func (s *WriteJoinStep) Accept(stmt *StatementImpl) error {
+	if !stmt.Dialect.SupportsMultiTableWrite() {
+		return fmt.Errorf("%s : multi table write is %w", stmt.Dialect.Name(), dialect.ErrorNotSupported)
+	}
+	stmt.State[StateWriteStmtJoin] = true
+	return (&SelectFromJoinStep{table: s.table, conditions: s.conditions, joinType: s.joinType}).Accept(stmt)
+}
+
+// WriteOrderByStep is ORDER BY of UPDATE and DELETE
+type WriteOrderByStep struct {
+	parent StatementAcceptor
+	orders []*model.SortOrder
+}
+
+func (s *WriteOrderByStep) Parent() StatementAcceptor {
+	return s.parent
+}
+
+func (s *WriteOrderByStep) Accept(stmt *StatementImpl) error {
+	if !stmt.Dialect.SupportsWriteOrderLimit() {
+		return fmt.Errorf("%s : order by in update and delete is %w", stmt.Dialect.Name(), dialect.ErrorNotSupported)
+	}
+	return (&SelectOrderByStep{orders: s.orders}).Accept(stmt)
+}
+
+// WriteLimitStep is LIMIT of UPDATE and DELETE
+type WriteLimitStep struct {
+	parent StatementAcceptor
+	limit  int32
+}
+
+func (s *WriteLimitStep) Parent() StatementAcceptor {
+	return s.parent
+}
+
+func (s *WriteLimitStep) Accept(stmt *StatementImpl) error {
+	if !stmt.Dialect.SupportsWriteOrderLimit() {
+		return fmt.Errorf("%s : limit in update and delete is %w", stmt.Dialect.Name(), dialect.ErrorNotSupported)
+	}
+	stmt.Statement += stmt.Dialect.LimitOffset(s.limit, 0) + " "
+	return nil
+}
+
+// ReturningStep returns the written rows of INSERT, UPDATE and DELETE
+type ReturningStep struct {
+	parent  StatementAcceptor
+	columns []model.ColumnField
+}
+
+func (s *ReturningStep) Parent() StatementAcceptor {
+	return s.parent
+}
+
+func (s *ReturningStep) Accept(stmt *StatementImpl) error {
+	if !stmt.Dialect.SupportsReturning() {
+		return fmt.Errorf("%s : returning is %w", stmt.Dialect.Name(), dialect.ErrorNotSupported)
+	}
+	if len(s.columns) == 0 {
+		return ErrorNoColumnInfo
+	}
+
+	// RETURNINGでは書き込み対象のテーブルのみ参照できるため、テーブル名で修飾しない
+	cols := make([]string, 0)
+	for _, column := range s.columns {
+		if _, ok := column.(*model.AllColumn); ok {
+			cols = append(cols, "*")
+			continue
+		}
+		col := stmt.Dialect.QuoteIdentifier(column.ColumnName())
+		if alias := column.AliasOrName(); alias != column.ColumnName() {
+			col += " AS " + stmt.Dialect.QuoteIdentifier(alias)
+		}
+		cols = append(cols, col)
+	}
+	stmt.Statement += fmt.Sprintf("RETURNING %s ", strings.Join(cols, ", "))
+	stmt.State[StateWriteStmtReturning] = true
+	return nil
+}
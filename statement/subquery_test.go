@@ -0,0 +1,122 @@
+package statement
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/tmarcus87/sqlike/dialect"
+	"github.com/tmarcus87/sqlike/model"
+	"testing"
+)
+
+func TestSubQuery(t *testing.T) {
+	t1 := model.NewTable("t1")
+	t1c1 := model.NewInt64Column(t1, "c1")
+	t1c2 := model.NewTextColumn(t1, "c2")
+
+	t2 := model.NewTable("t2")
+	t2c1 := model.NewInt64Column(t2, "c1")
+	t2c2 := model.NewTextColumn(t2, "c2")
+
+	t.Run("In", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		sq := NewSelectColumnBranchStep(root(dialect.Postgres), t2c1).From(t2).Where(t2c2.Eq("bar")).Build()
+
+		stmt, bindings, err :=
+			NewSelectColumnBranchStep(root(dialect.Postgres), t1c1).From(t1).
+				Where(t1c2.Eq("foo"), t1c1.InSubQuery(sq), t1c2.Eq("baz")).
+				Build().
+				StatementAndBindings()
+		asserts.Nil(err)
+		asserts.Equal(`SELECT "t1"."c1" FROM "t1" WHERE ("t1"."c2" = $1 AND "t1"."c1" IN (SELECT "t2"."c1" FROM "t2" WHERE "t2"."c2" = $2) AND "t1"."c2" = $3)`, stmt)
+		asserts.Equal([]interface{}{"foo", "bar", "baz"}, bindings)
+
+		// The sub query can be built by itself
+		stmt, _, err = sq.StatementAndBindings()
+		asserts.Nil(err)
+		asserts.Equal(`SELECT "t2"."c1" FROM "t2" WHERE "t2"."c2" = $1`, stmt)
+	})
+
+	t.Run("NotIn", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		stmt, _, err :=
+			NewSelectColumnBranchStep(root(dialect.MySQL), t1c1).From(t1).
+				Where(t1c1.NotInSubQuery(NewSelectColumnBranchStep(root(dialect.MySQL), t2c1).From(t2).Build())).
+				Build().
+				StatementAndBindings()
+		asserts.Nil(err)
+		asserts.Equal("SELECT `t1`.`c1` FROM `t1` WHERE `t1`.`c1` NOT IN (SELECT `t2`.`c1` FROM `t2`)", stmt)
+	})
+
+	t.Run("Exists", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		sq := NewSelectFromBranchStep(root(dialect.Postgres), t2).Where(t2c1.EqCol(t1c1), t2c2.Eq("bar")).Build()
+
+		stmt, bindings, err :=
+			NewSelectColumnBranchStep(root(dialect.Postgres), t1c1).From(t1).
+				Where(model.Exists(sq).Or(model.NotExists(sq))).
+				Build().
+				StatementAndBindings()
+		asserts.Nil(err)
+		asserts.Equal(`SELECT "t1"."c1" FROM "t1" WHERE (EXISTS (SELECT * FROM "t2" WHERE ("t2"."c1" = "t1"."c1" AND "t2"."c2" = $1)) OR NOT EXISTS (SELECT * FROM "t2" WHERE ("t2"."c1" = "t1"."c1" AND "t2"."c2" = $2)))`, stmt)
+		asserts.Equal([]interface{}{"bar", "bar"}, bindings)
+	})
+
+	t.Run("DerivedTable", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		dt := model.NewDerivedTable(
+			NewSelectColumnBranchStep(root(dialect.Postgres), t2c1, t2c2).From(t2).Where(t2c2.Eq("bar")).Build(),
+			"d")
+		dc1 := model.NewInt64Column(dt, "c1")
+
+		stmt, bindings, err :=
+			NewSelectColumnBranchStep(root(dialect.Postgres), t1c1, dc1).From(t1).
+				InnerJoin(dt, dc1.EqCol(t1c1), t1c2.Eq("foo")).
+				Where(dc1.Gt(10)).
+				Build().
+				StatementAndBindings()
+		asserts.Nil(err)
+		asserts.Equal(`SELECT "t1"."c1", "d"."c1" FROM "t1" INNER JOIN (SELECT "t2"."c1", "t2"."c2" FROM "t2" WHERE "t2"."c2" = $1) AS "d" ON ("d"."c1" = "t1"."c1" AND "t1"."c2" = $2) WHERE "d"."c1" > $3`, stmt)
+		asserts.Equal([]interface{}{"bar", "foo", int64(10)}, bindings)
+	})
+
+	t.Run("FromDerivedTable", func(t *testing.T) {
+		asserts := assert.New(t)
+
+		dt := model.NewDerivedTable(
+			NewSelectColumnBranchStep(root(dialect.MySQL), t2c1).From(t2).Where(t2c2.Eq("bar")).LimitAndOffset(10, 0).Build(),
+			"d")
+
+		stmt, bindings, err := NewSelectFromBranchStep(root(dialect.MySQL), dt).Build().StatementAndBindings()
+		asserts.Nil(err)
+		asserts.Equal("SELECT * FROM (SELECT `t2`.`c1` FROM `t2` WHERE `t2`.`c2` = ? LIMIT 10) AS `d`", stmt)
+		asserts.Equal([]interface{}{"bar"}, bindings)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		sq := NewSelectFromBranchStep(root(dialect.MySQL), t2).Where().SeekAfter(nil).Build()
+
+		_, _, err := NewSelectFromBranchStep(root(dialect.MySQL), t1).Where(t1c1.InSubQuery(sq)).Build().StatementAndBindings()
+		assert.True(t, errors.Is(err, ErrorSeekNoOrders))
+	})
+
+	t.Run("DerivedTableError", func(t *testing.T) {
+		dt := model.NewDerivedTable(NewSelectFromBranchStep(root(dialect.MySQL), t2).Where().SeekAfter(nil).Build(), "d")
+
+		_, _, err := NewSelectFromBranchStep(root(dialect.MySQL), dt).Build().StatementAndBindings()
+		assert.True(t, errors.Is(err, ErrorSeekNoOrders))
+
+		_, _, err = NewDeleteFromBranchStep(root(dialect.MySQL), dt).AllRows().Build().StatementAndBindings()
+		assert.True(t, errors.Is(err, ErrorSeekNoOrders))
+	})
+
+	t.Run("NativeStatement", func(t *testing.T) {
+		sq := NewInstantStep(root(dialect.MySQL), "SELECT c1 FROM t2", nil)
+
+		_, _, err := NewSelectFromBranchStep(root(dialect.MySQL), t1).Where(model.Exists(sq)).Build().StatementAndBindings()
+		assert.True(t, errors.Is(err, ErrorNativeSubQuery))
+	})
+}
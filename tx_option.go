@@ -0,0 +1,79 @@
+package sqlike
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/go-sql-driver/mysql"
+	"time"
+)
+
+const (
+	mysqlErrorLockWaitTimeout = 1205
+	mysqlErrorDeadlock        = 1213
+)
+
+type TxOptions struct {
+	sql.TxOptions
+
+	// MaxRetries is the max number of retries of RunInTx
+	MaxRetries int
+	// Backoff returns the duration to wait before the retry(0-origin)
+	Backoff func(attempt int) time.Duration
+	// RetryIf returns whether the error is retryable or not
+	RetryIf func(err error) bool
+}
+
+type TxOption func(o *TxOptions)
+
+func newTxOptions(opts ...TxOption) *TxOptions {
+	o := TxOptions{
+		Backoff: func(int) time.Duration { return 0 },
+		RetryIf: IsRetryableError,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &o
+}
+
+// WithIsolationLevel sets the isolation level of the transaction
+func WithIsolationLevel(level sql.IsolationLevel) TxOption {
+	return func(o *TxOptions) {
+		o.Isolation = level
+	}
+}
+
+// WithReadOnly makes the transaction read-only
+func WithReadOnly() TxOption {
+	return func(o *TxOptions) {
+		o.ReadOnly = true
+	}
+}
+
+// WithRetry retries RunInTx up to maxRetries times
+//
+// The wait before the retry is doubled from the backoff on each retry.
+func WithRetry(maxRetries int, backoff time.Duration) TxOption {
+	return func(o *TxOptions) {
+		o.MaxRetries = maxRetries
+		o.Backoff = func(attempt int) time.Duration {
+			return backoff << uint(attempt)
+		}
+	}
+}
+
+// WithRetryIf replaces the condition of the retryable error
+func WithRetryIf(retryIf func(err error) bool) TxOption {
+	return func(o *TxOptions) {
+		o.RetryIf = retryIf
+	}
+}
+
+// IsRetryableError returns true when the error is deadlock or lock wait timeout of MySQL
+func IsRetryableError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlErrorDeadlock || mysqlErr.Number == mysqlErrorLockWaitTimeout
+	}
+	return false
+}
@@ -22,10 +22,134 @@ func RecordWithOnly(value interface{}, cols ...model.Column) *model.Record {
 	}
 }
 
-func Count(field model.ColumnField) model.ColumnField {
+func Count(field model.ColumnField) *model.CountColumnModifier {
 	return model.Count(field)
 }
 
+func CountDistinct(field model.ColumnField) *model.CountColumnModifier {
+	return model.CountDistinct(field)
+}
+
+func CountAll() *model.CountColumnModifier {
+	return model.CountAll()
+}
+
+func Sum(field model.ColumnField) *model.AggregateColumnModifier {
+	return model.Sum(field)
+}
+
+func Avg(field model.ColumnField) *model.Float64Column {
+	return model.Avg(field)
+}
+
+func Min(field model.ColumnField) *model.AggregateColumnModifier {
+	return model.Min(field)
+}
+
+func Max(field model.ColumnField) *model.AggregateColumnModifier {
+	return model.Max(field)
+}
+
+func GroupConcat(field model.ColumnField) *model.GroupConcatColumnModifier {
+	return model.GroupConcat(field)
+}
+
 func Distinct(field model.ColumnField) model.ColumnField {
 	return model.Distinct(field)
 }
+
+func RowNumber() *model.WindowFunction {
+	return model.RowNumber()
+}
+
+func Rank() *model.WindowFunction {
+	return model.Rank()
+}
+
+func DenseRank() *model.WindowFunction {
+	return model.DenseRank()
+}
+
+func NTile(n int) *model.WindowFunction {
+	return model.NTile(n)
+}
+
+func Lag(field model.ColumnField, offset int) *model.WindowFunction {
+	return model.Lag(field, offset)
+}
+
+func Lead(field model.ColumnField, offset int) *model.WindowFunction {
+	return model.Lead(field, offset)
+}
+
+func FirstValue(field model.ColumnField) *model.WindowFunction {
+	return model.FirstValue(field)
+}
+
+func LastValue(field model.ColumnField) *model.WindowFunction {
+	return model.LastValue(field)
+}
+
+func Aggregate(field model.ColumnField) *model.WindowFunction {
+	return model.Aggregate(field)
+}
+
+func PartitionBy(columns ...model.ColumnField) model.WindowClause {
+	return model.PartitionBy(columns...)
+}
+
+func OrderBy(orders ...*model.SortOrder) model.WindowClause {
+	return model.OrderBy(orders...)
+}
+
+func RowsBetween(start, end model.FrameBound) model.WindowClause {
+	return model.RowsBetween(start, end)
+}
+
+func RangeBetween(start, end model.FrameBound) model.WindowClause {
+	return model.RangeBetween(start, end)
+}
+
+func CaseWhen(condition model.Condition, then interface{}) *model.CaseExpr {
+	return model.CaseWhen(condition, then)
+}
+
+func Coalesce(args ...interface{}) *model.Expr {
+	return model.Coalesce(args...)
+}
+
+func IfNull(v, alternative interface{}) *model.Expr {
+	return model.IfNull(v, alternative)
+}
+
+func Lower(v interface{}) *model.Expr {
+	return model.Lower(v)
+}
+
+func Upper(v interface{}) *model.Expr {
+	return model.Upper(v)
+}
+
+func Concat(args ...interface{}) *model.Expr {
+	return model.Concat(args...)
+}
+
+func DateFormat(v interface{}, format string) *model.Expr {
+	return model.DateFormat(v, format)
+}
+
+func Now() *model.Expr {
+	return model.Now()
+}
+
+func Cast(v interface{}, dataType string) *model.Expr {
+	return model.Cast(v, dataType)
+}
+
+func ColumnOf(column model.Column) *model.Expr {
+	return model.ColumnOf(column)
+}
+
+func InsertedValue(column model.Column) *model.Expr {
+	return model.InsertedValue(column)
+}